@@ -11,7 +11,6 @@ require (
 	github.com/hashicorp/go-version v1.3.0
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.7.0
 	github.com/jen20/awspolicyequivalence v1.1.0
-	github.com/jinzhu/copier v0.2.3
 	github.com/mitchellh/go-homedir v1.1.0
 	github.com/opentelekomcloud/gophertelekomcloud v0.4.2-0.20210713140005-7d7148852255
 	github.com/unknwon/com v1.0.1
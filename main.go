@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud"
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/cfg"
 )
 
 func main() {
@@ -16,10 +17,12 @@ func main() {
 	flag.Parse()
 
 	opts := &plugin.ServeOpts{ProviderFunc: opentelekomcloud.Provider}
+	defer closeFiles()
 
 	if debugMode {
 		err := plugin.Debug(context.Background(), "registry.terraform.io/opentelekomcloud/opentelekomcloud", opts)
 		if err != nil {
+			closeFiles()
 			log.Fatal(err.Error())
 		}
 		return
@@ -27,3 +30,13 @@ func main() {
 
 	plugin.Serve(opts)
 }
+
+// closeFiles closes HTTP cassettes and trace files opened by the provider
+func closeFiles() {
+	if err := cfg.CloseCassettes(); err != nil {
+		log.Printf("[WARN] Error closing HTTP cassette: %s", err)
+	}
+	if err := cfg.CloseTracers(); err != nil {
+		log.Printf("[WARN] Error closing trace file: %s", err)
+	}
+}
@@ -29,6 +29,11 @@ func TestAccASV1Configuration_basic(t *testing.T) {
 					testAccCheckASV1ConfigurationExists(resourceName, &asConfig),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -43,6 +43,14 @@ func TestAccASV1Group_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "tags.muh", "value-update"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"delete_instances",
+				},
+			},
 		},
 	})
 }
@@ -29,6 +29,11 @@ func TestAccASV1Policy_basic(t *testing.T) {
 					testAccCheckASV1PolicyExists("opentelekomcloud_as_policy_v1.hth_as_policy", &asPolicy),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_as_policy_v1.hth_as_policy",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -40,6 +40,11 @@ func TestAccASPolicyV2_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "cool_down_time", "100"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -38,6 +38,17 @@ func TestAccComputeV2BmsInstance_basic(t *testing.T) {
 						"opentelekomcloud_compute_bms_server_v2.instance_1", "name", "instance_2"),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_compute_bms_server_v2.instance_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"admin_pass",
+					"user_data",
+					"block_device",
+					"stop_before_destroy",
+				},
+			},
 		},
 	})
 }
@@ -38,6 +38,11 @@ func TestAccCBRPolicyV3_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("opentelekomcloud_cbr_policy_v3.policy", "enabled", "false"),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_cbr_policy_v3.policy",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
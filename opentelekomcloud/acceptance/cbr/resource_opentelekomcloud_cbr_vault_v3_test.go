@@ -37,6 +37,11 @@ func TestAccCBRVaultV3_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("opentelekomcloud_cbr_vault_v3.vault", "billing.0.size", "120"),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_cbr_vault_v3.vault",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -0,0 +1,73 @@
+package acceptance
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/acceptance/common"
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/acceptance/env"
+)
+
+func TestAccCCEAddonTemplateV3DataSource_basic(t *testing.T) {
+	dataSourceName := "data.opentelekomcloud_cce_addon_template_v3.autoscaler"
+	cceName := fmt.Sprintf("cce-test-%s", acctest.RandString(5))
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { common.TestAccPreCheck(t) },
+		ProviderFactories: common.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCCEAddonTemplateV3DataSourceBasic(cceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "addon_version", "1.19.1"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "available_versions.#"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "basic_json"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "custom_json"),
+					resource.TestCheckResourceAttrPair(
+						"opentelekomcloud_cce_addon_v3.autoscaler", "template_version",
+						dataSourceName, "addon_version",
+					),
+				),
+			},
+		},
+	})
+}
+
+func testAccCCEAddonTemplateV3DataSourceBasic(cceName string) string {
+	return fmt.Sprintf(`
+resource opentelekomcloud_cce_cluster_v3 cluster_1 {
+  name                    = "%s"
+  cluster_type            = "VirtualMachine"
+  flavor_id               = "cce.s1.small"
+  vpc_id                  = "%s"
+  subnet_id               = "%s"
+  container_network_type  = "overlay_l2"
+  kubernetes_svc_ip_range = "10.247.0.0/16"
+}
+
+data "opentelekomcloud_cce_addon_template_v3" "autoscaler" {
+  cluster_id    = opentelekomcloud_cce_cluster_v3.cluster_1.id
+  addon_name    = "autoscaler"
+  addon_version = "1.19.1"
+}
+
+resource "opentelekomcloud_cce_addon_v3" "autoscaler" {
+  template_name    = data.opentelekomcloud_cce_addon_template_v3.autoscaler.addon_name
+  template_version = data.opentelekomcloud_cce_addon_template_v3.autoscaler.addon_version
+  cluster_id       = opentelekomcloud_cce_cluster_v3.cluster_1.id
+
+  values {
+    basic  = jsondecode(data.opentelekomcloud_cce_addon_template_v3.autoscaler.basic_json)
+    custom = merge(
+      jsondecode(data.opentelekomcloud_cce_addon_template_v3.autoscaler.custom_json),
+      {
+        cluster_id = opentelekomcloud_cce_cluster_v3.cluster_1.id
+        tenant_id  = "%s"
+      }
+    )
+  }
+}
+`, cceName, env.OS_VPC_ID, env.OS_NETWORK_ID, env.OS_TENANT_ID)
+}
@@ -0,0 +1,56 @@
+package acceptance
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/acceptance/common"
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/acceptance/env"
+)
+
+func TestAccCCEClusterKubeConfigV3DataSource_basic(t *testing.T) {
+	dataSourceName := "data.opentelekomcloud_cce_cluster_kubeconfig_v3.config"
+	cceName := fmt.Sprintf("cce-test-%s", acctest.RandString(5))
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { common.TestAccPreCheck(t) },
+		ProviderFactories: common.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCCEClusterKubeConfigV3DataSourceBasic(cceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						dataSourceName, "host",
+						"opentelekomcloud_cce_cluster_v3.cluster_1", "internal",
+					),
+					resource.TestCheckResourceAttrSet(dataSourceName, "kubeconfig"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "cluster_ca_certificate"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "client_certificate"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "client_key"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCCEClusterKubeConfigV3DataSourceBasic(cceName string) string {
+	return fmt.Sprintf(`
+resource opentelekomcloud_cce_cluster_v3 cluster_1 {
+  name                    = "%s"
+  cluster_type            = "VirtualMachine"
+  flavor_id               = "cce.s1.small"
+  vpc_id                  = "%s"
+  subnet_id               = "%s"
+  container_network_type  = "overlay_l2"
+  kubernetes_svc_ip_range = "10.247.0.0/16"
+}
+
+data "opentelekomcloud_cce_cluster_kubeconfig_v3" "config" {
+  cluster_id = opentelekomcloud_cce_cluster_v3.cluster_1.id
+  context    = "internal"
+  duration   = 1
+}
+`, cceName, env.OS_VPC_ID, env.OS_NETWORK_ID)
+}
@@ -14,6 +14,7 @@ import (
 
 func TestAccCCEAddonV3Basic(t *testing.T) {
 	resName := "opentelekomcloud_cce_addon_v3.autoscaler"
+	var addonID string
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { common.TestAccPreCheck(t) },
 		ProviderFactories: common.TestAccProviderFactories,
@@ -24,12 +25,14 @@ func TestAccCCEAddonV3Basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					checkScaleDownForAutoscaler(resName, true),
 					resource.TestCheckResourceAttr(resName, "values.0.custom.scaleDownDelayAfterDelete", "11"),
+					checkAddonID(resName, &addonID, false),
 				),
 			},
 			{
 				Config: testAccCCEAddonV3Updated,
 				Check: resource.ComposeTestCheckFunc(
 					checkScaleDownForAutoscaler(resName, false),
+					checkAddonID(resName, &addonID, true),
 					resource.TestCheckResourceAttr(resName, "values.0.custom.scaleDownDelayAfterDelete", "8"),
 				),
 			},
@@ -271,3 +274,18 @@ func checkScaleDownForAutoscaler(name string, enabled bool) resource.TestCheckFu
 		return nil
 	}
 }
+
+// checkAddonID saves the addon ID or, if `same` is set, checks that addon is not re-created
+func checkAddonID(name string, id *string, same bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("not found: %s", name)
+		}
+		if same && rs.Primary.ID != *id {
+			return fmt.Errorf("addon was re-created: ID changed from %s to %s", *id, rs.Primary.ID)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
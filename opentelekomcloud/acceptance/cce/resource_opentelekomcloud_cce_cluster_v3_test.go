@@ -205,6 +205,33 @@ func TestAccCCEClusterV3_withVersionDiff(t *testing.T) {
 	})
 }
 
+func TestAccCCEClusterV3_upgrade(t *testing.T) {
+	var cluster clusters.Clusters
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { common.TestAccPreCheck(t) },
+		ProviderFactories: common.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckCCEClusterV3Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCCEClusterV3Version("v1.17.9-r0"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCEClusterV3Exists(resourceName, &cluster),
+					resource.TestCheckResourceAttr(resourceName, "cluster_version", "v1.17.9-r0"),
+				),
+			},
+			{
+				Config: testAccCCEClusterV3Version("v1.19.8-r0"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(resourceName, "id", &cluster.Metadata.Id),
+					resource.TestCheckResourceAttr(resourceName, "cluster_version", "v1.19.8-r0"),
+					resource.TestCheckResourceAttr(resourceName, "status", "Available"),
+				),
+			},
+		},
+	})
+}
+
 var (
 	testAccCCEClusterV3Basic = fmt.Sprintf(`
 resource "opentelekomcloud_cce_cluster_v3" "cluster_1" {
@@ -386,3 +413,18 @@ resource "opentelekomcloud_cce_cluster_v3" "cluster_1" {
   no_addons               = true
 }`, clusterName, env.OS_VPC_ID, env.OS_NETWORK_ID)
 )
+
+func testAccCCEClusterV3Version(clusterVersion string) string {
+	return fmt.Sprintf(`
+resource "opentelekomcloud_cce_cluster_v3" "cluster_1" {
+  name                    = "%s"
+  cluster_type            = "VirtualMachine"
+  flavor_id               = "cce.s1.small"
+  cluster_version         = "%s"
+  vpc_id                  = "%s"
+  subnet_id               = "%s"
+  container_network_type  = "overlay_l2"
+  kubernetes_svc_ip_range = "10.247.0.0/16"
+  upgrade_addons          = true
+}`, clusterName, clusterVersion, env.OS_VPC_ID, env.OS_NETWORK_ID)
+}
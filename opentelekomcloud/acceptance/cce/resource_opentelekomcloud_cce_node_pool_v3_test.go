@@ -86,6 +86,43 @@ func TestAccCCENodePoolsV3EncryptedVolume(t *testing.T) {
 	})
 }
 
+func TestAccCCENodePoolsV3_rollingUpdate(t *testing.T) {
+	var nodePool nodepools.NodePool
+	nodePoolName := "opentelekomcloud_cce_node_pool_v3.node_pool"
+	clusterName := "opentelekomcloud_cce_cluster_v3.cluster"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccCCEKeyPairPreCheck(t) },
+		ProviderFactories: common.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckCCENodePoolV3Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCCENodePoolV3Rolling("s2.xlarge.2", "muh"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCENodePoolV3Exists(nodePoolName, clusterName, &nodePool),
+					resource.TestCheckResourceAttr(nodePoolName, "flavor", "s2.xlarge.2"),
+				),
+			},
+			{
+				Config: testAccCCENodePoolV3Rolling("s2.xlarge.2", "kuh"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCENodePoolV3ID(nodePoolName, &nodePool, true),
+					resource.TestCheckResourceAttr(nodePoolName, "k8s_tags.kubelet.kubernetes.io/namespace", "kuh"),
+				),
+			},
+			{
+				Config: testAccCCENodePoolV3Rolling("s2.large.2", "kuh"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCENodePoolV3ID(nodePoolName, &nodePool, false),
+					resource.TestCheckResourceAttr(nodePoolName, "flavor", "s2.large.2"),
+					resource.TestCheckResourceAttr(nodePoolName, "name", "opentelekomcloud-cce-node-pool"),
+					resource.TestCheckResourceAttr(nodePoolName, "initial_node_count", "2"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckCCENodePoolV3Destroy(s *terraform.State) error {
 	config := common.TestAccProvider.Meta().(*cfg.Config)
 	cceClient, err := config.CceV3Client(env.OS_REGION_NAME)
@@ -152,6 +189,20 @@ func testAccCheckCCENodePoolV3Exists(n string, cluster string, nodePool *nodepoo
 	}
 }
 
+// testAccCheckCCENodePoolV3ID checks if the node pool is the same one as the given pool or not
+func testAccCheckCCENodePoolV3ID(n string, nodePool *nodepools.NodePool, same bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		if (rs.Primary.ID == nodePool.Metadata.Id) != same {
+			return fmt.Errorf("unexpected node pool ID: %s, previous ID: %s", rs.Primary.ID, nodePool.Metadata.Id)
+		}
+		return nil
+	}
+}
+
 var (
 	testAccCCENodePoolV3Basic = fmt.Sprintf(`
 resource "opentelekomcloud_cce_cluster_v3" "cluster" {
@@ -310,3 +361,45 @@ resource "opentelekomcloud_cce_node_pool_v3" "node_pool" {
   }
 }`, env.OS_VPC_ID, env.OS_NETWORK_ID, env.OS_KEYPAIR_NAME, env.OS_KMS_ID)
 )
+
+func testAccCCENodePoolV3Rolling(flavor, namespace string) string {
+	return fmt.Sprintf(`
+resource "opentelekomcloud_cce_cluster_v3" "cluster" {
+  name         = "opentelekomcloud-cce-np"
+  cluster_type = "VirtualMachine"
+  flavor_id    = "cce.s1.small"
+  vpc_id       = "%s"
+  subnet_id    = "%s"
+
+  container_network_type = "overlay_l2"
+  authentication_mode    = "rbac"
+}
+
+resource "opentelekomcloud_cce_node_pool_v3" "node_pool" {
+  cluster_id         = opentelekomcloud_cce_cluster_v3.cluster.id
+  name               = "opentelekomcloud-cce-node-pool"
+  os                 = "EulerOS 2.5"
+  flavor             = "%s"
+  initial_node_count = 2
+  availability_zone  = "%s"
+  key_pair           = "%s"
+
+  root_volume {
+    size       = 40
+    volumetype = "SSD"
+  }
+  data_volumes {
+    size       = 100
+    volumetype = "SSD"
+  }
+
+  k8s_tags = {
+    "kubelet.kubernetes.io/namespace" = "%s"
+  }
+
+  rolling_update {
+    max_surge       = 1
+    max_unavailable = 1
+  }
+}`, env.OS_VPC_ID, env.OS_NETWORK_ID, flavor, env.OS_AVAILABILITY_ZONE, env.OS_KEYPAIR_NAME, namespace)
+}
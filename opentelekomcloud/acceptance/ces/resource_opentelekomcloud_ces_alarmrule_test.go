@@ -34,6 +34,11 @@ func TestCESAlarmRule_basic(t *testing.T) {
 						"opentelekomcloud_ces_alarmrule.alarmrule_1", "alarm_enabled", "false"),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_ces_alarmrule.alarmrule_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
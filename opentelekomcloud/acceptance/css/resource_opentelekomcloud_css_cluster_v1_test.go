@@ -40,6 +40,14 @@ func TestAccCssClusterV1_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "nodes.#", "2"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"admin_pass",
+				},
+			},
 		},
 	})
 }
@@ -38,6 +38,15 @@ func TestResourceCSSSnapshotConfigurationV1_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "creation_policy.0.keepday", "2"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"automatic",
+					"creation_policy.0.delete_auto",
+				},
+			},
 		},
 	})
 }
@@ -28,6 +28,12 @@ func TestAccELBBackend_basic(t *testing.T) {
 					testAccCheckELBBackendExists("opentelekomcloud_elb_backend.backend_1", &backend),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_elb_backend.backend_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccELBBackendImportStateIdFunc("opentelekomcloud_elb_backend.backend_1"),
+			},
 		},
 	})
 }
@@ -139,3 +145,13 @@ resource "opentelekomcloud_elb_backend" "backend_1" {
   }
 }
 `, env.OS_AVAILABILITY_ZONE, env.OS_NETWORK_ID, env.OS_VPC_ID)
+
+func testAccELBBackendImportStateIdFunc(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", name)
+		}
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["listener_id"], rs.Primary.ID), nil
+	}
+}
@@ -37,6 +37,11 @@ func TestAccELBHealth_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("opentelekomcloud_elb_health.health_1", "healthcheck_timeout", "15"),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_elb_health.health_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
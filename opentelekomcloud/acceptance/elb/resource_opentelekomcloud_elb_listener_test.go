@@ -36,6 +36,11 @@ func TestAccELBListener_basic(t *testing.T) {
 						"opentelekomcloud_elb_listener.listener_1", "backend_port", "8088"),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_elb_listener.listener_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -36,6 +36,11 @@ func TestAccELBLoadBalancer_basic(t *testing.T) {
 						"opentelekomcloud_elb_loadbalancer.loadbalancer_1", "name", "loadbalancer_1_updated"),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_elb_loadbalancer.loadbalancer_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
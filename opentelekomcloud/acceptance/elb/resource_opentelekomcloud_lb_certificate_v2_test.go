@@ -48,6 +48,15 @@ func TestAccLBV2Certificate_basic(t *testing.T) {
 						"opentelekomcloud_lb_certificate_v2.certificate_ca", "name", "certificate_client_updated"),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_lb_certificate_v2.certificate_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"certificate",
+					"private_key",
+				},
+			},
 		},
 	})
 }
@@ -38,6 +38,12 @@ func TestAccLBV2Member_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("opentelekomcloud_lb_member_v2.member_2", "weight", "15"),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_lb_member_v2.member_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccLBV2MemberImportStateIdFunc("opentelekomcloud_lb_member_v2.member_1"),
+			},
 		},
 	})
 }
@@ -194,3 +200,13 @@ resource "opentelekomcloud_lb_member_v2" "member_2" {
   }
 }
 `, env.OS_SUBNET_ID)
+
+func testAccLBV2MemberImportStateIdFunc(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", name)
+		}
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["pool_id"], rs.Primary.ID), nil
+	}
+}
@@ -38,6 +38,11 @@ func TestAccLBV2Pool_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "admin_state_up", "true"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
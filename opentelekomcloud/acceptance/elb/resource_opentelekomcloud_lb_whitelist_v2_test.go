@@ -33,6 +33,11 @@ func TestAccLBV2Whitelist_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("opentelekomcloud_lb_whitelist_v2.whitelist_1", "enable_whitelist", "true"),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_lb_whitelist_v2.whitelist_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -46,6 +46,11 @@ func TestAccNatGateway_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("opentelekomcloud_nat_gateway_v2.nat_1", "spec", "2"),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_nat_gateway_v2.nat_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
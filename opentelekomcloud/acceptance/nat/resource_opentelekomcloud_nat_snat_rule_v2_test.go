@@ -42,6 +42,11 @@ func TestAccNatSnatRule_basic(t *testing.T) {
 					testAccCheckNatV2SnatRuleExists("opentelekomcloud_nat_snat_rule_v2.snat_1"),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_nat_snat_rule_v2.snat_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
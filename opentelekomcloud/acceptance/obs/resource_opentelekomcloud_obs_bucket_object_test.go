@@ -80,6 +80,20 @@ func TestAccObsBucketObject_content(t *testing.T) {
 						"opentelekomcloud_obs_bucket_object.object", "size", "19"),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_obs_bucket_object.object",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccObsBucketObjectImportStateIdFunc("opentelekomcloud_obs_bucket_object.object"),
+				ImportStateVerifyIgnore: []string{
+					"content",
+					"acl",
+					"encryption",
+					"kms_key_id",
+					"content_type",
+					"version_id",
+				},
+			},
 		},
 	})
 }
@@ -277,3 +291,13 @@ CONTENT
 }
 `, randInt)
 }
+
+func testAccObsBucketObjectImportStateIdFunc(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", name)
+		}
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["bucket"], rs.Primary.ID), nil
+	}
+}
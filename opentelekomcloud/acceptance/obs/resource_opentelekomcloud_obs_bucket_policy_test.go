@@ -36,6 +36,11 @@ func TestAccObsBucketPolicyBasic(t *testing.T) {
 					testAccCheckObsBucketHasPolicy(resourceName, expectedPolicyText),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_obs_bucket_policy.bucket",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
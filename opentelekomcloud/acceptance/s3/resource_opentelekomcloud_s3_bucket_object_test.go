@@ -63,6 +63,16 @@ func TestAccS3BucketObject_content(t *testing.T) {
 				Config:    testAccS3BucketObjectConfigContent(rInt),
 				Check:     testAccCheckS3BucketObjectExists("opentelekomcloud_s3_bucket_object.object", &obj),
 			},
+			{
+				ResourceName:      "opentelekomcloud_s3_bucket_object.object",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccS3BucketObjectImportStateIdFunc("opentelekomcloud_s3_bucket_object.object"),
+				ImportStateVerifyIgnore: []string{
+					"content",
+					"acl",
+				},
+			},
 		},
 	})
 }
@@ -586,3 +596,13 @@ func validateS3BucketObjectStorageClassType(v interface{}, k string) (ws []strin
 	}
 	return
 }
+
+func testAccS3BucketObjectImportStateIdFunc(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", name)
+		}
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["bucket"], rs.Primary.ID), nil
+	}
+}
@@ -35,6 +35,11 @@ func TestAccS3BucketPolicy_basic(t *testing.T) {
 					testAccCheckS3BucketHasPolicy("opentelekomcloud_s3_bucket.bucket", expectedPolicyText),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_s3_bucket_policy.bucket",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -36,6 +36,11 @@ func TestAccSMNV2Subscription_basic(t *testing.T) {
 						"opentelekomcloud_smn_subscription_v2.subscription_2", "endpoint", "13600000000"),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_smn_subscription_v2.subscription_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
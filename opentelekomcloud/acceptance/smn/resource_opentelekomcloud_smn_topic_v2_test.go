@@ -43,6 +43,11 @@ func TestAccSMNV2Topic_basic(t *testing.T) {
 						"opentelekomcloud_smn_topic_v2.topic_1", "name", "topic_1"),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_smn_topic_v2.topic_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -32,6 +32,15 @@ func TestSwrDomainV2Basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceDomainName, "access_domain", domainToShare),
 				),
 			},
+			{
+				ResourceName:      resourceDomainName,
+				ImportStateId:     fmt.Sprintf("%[1]s/%[1]s/%[2]s", name, domainToShare),
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"deadline",
+				},
+			},
 		},
 	})
 }
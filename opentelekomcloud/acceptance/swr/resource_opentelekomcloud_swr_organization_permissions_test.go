@@ -34,6 +34,12 @@ func TestSwrOrganizationPermissionsV2_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourcePermissionsName, "auth", "3"),
 				),
 			},
+			{
+				ResourceName:      resourcePermissionsName,
+				ImportStateId:     fmt.Sprintf("%s/%s", name, userID),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -0,0 +1,34 @@
+package acceptance
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/acceptance/common"
+)
+
+func TestAccQuotasV1DataSource_basic(t *testing.T) {
+	dataSourceName := "data.opentelekomcloud_quotas_v1.quotas"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { common.TestAccPreCheck(t) },
+		ProviderFactories: common.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccQuotasV1DataSourceBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "limit.instances"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "used.instances"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "limit.gigabytes"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "limit.vpcs"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "used.security_group_rules"),
+				),
+			},
+		},
+	})
+}
+
+const testAccQuotasV1DataSourceBasic = `
+data "opentelekomcloud_quotas_v1" "quotas" {}
+`
@@ -36,6 +36,11 @@ func TestAccNetworkingV2RouterInterface_basic_subnet(t *testing.T) {
 					TestAccCheckNetworkingV2RouterInterfaceExists("opentelekomcloud_networking_router_interface_v2.int_1"),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_networking_router_interface_v2.int_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -61,6 +66,11 @@ func TestAccNetworkingV2RouterInterface_basic_port(t *testing.T) {
 					TestAccCheckNetworkingV2RouterInterfaceExists("opentelekomcloud_networking_router_interface_v2.int_1"),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_networking_router_interface_v2.int_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -54,6 +54,11 @@ func TestAccNetworkingV2RouterRoute_basic(t *testing.T) {
 						"opentelekomcloud_networking_router_route_v2.router_route_2"),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_networking_router_route_v2.router_route_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 			{
 				Config: testAccNetworkingV2RouterRoute_destroy,
 				Check: resource.ComposeTestCheckFunc(
@@ -35,6 +35,14 @@ func TestAccNetworkingV2Router_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "name", "router_2"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"value_specs",
+				},
+			},
 		},
 	})
 }
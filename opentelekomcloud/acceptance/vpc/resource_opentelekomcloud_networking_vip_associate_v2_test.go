@@ -37,6 +37,11 @@ func TestAccNetworkingV2VIPAssociate_basic(t *testing.T) {
 					testAccCheckNetworkingV2VIPAssociateAssociated(&port2, &vip),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_networking_vip_associate_v2.vip_associate_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -29,6 +29,11 @@ func TestAccNetworkingV2VIP_basic(t *testing.T) {
 					testAccCheckNetworkingV2VIPExists("opentelekomcloud_networking_vip_v2.vip_1", &vip),
 				),
 			},
+			{
+				ResourceName:      "opentelekomcloud_networking_vip_v2.vip_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
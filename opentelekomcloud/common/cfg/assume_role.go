@@ -0,0 +1,137 @@
+package cfg
+
+import (
+	"fmt"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack"
+)
+
+// AssumeRole is a single hop of the agency chain: the agency `AgencyName` created in the
+// domain `DomainName` is assumed using the token of the previous hop
+type AssumeRole struct {
+	AgencyName string
+	DomainName string
+	// DelegatedProject is the project the token of the last hop is scoped to
+	DelegatedProject string
+	// Duration is the validity period of the agency token in seconds
+	Duration int
+	// SessionName identifies the session in the CTS traces
+	SessionName string
+}
+
+// assumeRoleOptions authenticate with the base options and then assume agencies one after another
+type assumeRoleOptions struct {
+	base  golangsdk.AuthOptionsProvider
+	roles []AssumeRole
+	// domainOnly makes the last hop token scoped to the agency domain instead of the delegated project
+	domainOnly bool
+}
+
+func (opts assumeRoleOptions) GetIdentityEndpoint() string {
+	return opts.base.GetIdentityEndpoint()
+}
+
+// authenticate authenticates the client with the base options and then assumes the agencies.
+// AK/SK signing is used only for assuming the first agency, all next requests use the agency token.
+func (opts assumeRoleOptions) authenticate(client *golangsdk.ProviderClient) error {
+	if err := openstack.Authenticate(client, opts.base); err != nil {
+		return err
+	}
+	if client.AKSKAuthOptions.AccessKey != "" && client.AKSKAuthOptions.DomainID == "" {
+		return fmt.Errorf("domain_name or domain_id must be set to assume an agency with AK/SK")
+	}
+
+	for i, role := range opts.roles {
+		hop := &assumeRoleAuthOptions{
+			role:       role,
+			domainOnly: opts.domainOnly || i != len(opts.roles)-1,
+		}
+		if err := openstack.AuthenticateV3(client, hop, golangsdk.EndpointOpts{}); err != nil {
+			return fmt.Errorf("error assuming agency %s of domain %s: %w", role.AgencyName, role.DomainName, err)
+		}
+		client.AKSKAuthOptions = golangsdk.AKSKAuthOptions{}
+	}
+	return nil
+}
+
+// assumeRoleAuthOptions builds the request of the agency token
+type assumeRoleAuthOptions struct {
+	role       AssumeRole
+	domainOnly bool
+}
+
+func (opts *assumeRoleAuthOptions) ToTokenV3ScopeMap() (map[string]interface{}, error) {
+	domain := map[string]interface{}{
+		"name": opts.role.DomainName,
+	}
+	if opts.domainOnly || opts.role.DelegatedProject == "" {
+		return map[string]interface{}{"domain": domain}, nil
+	}
+	return map[string]interface{}{
+		"project": map[string]interface{}{
+			"name":   opts.role.DelegatedProject,
+			"domain": domain,
+		},
+	}, nil
+}
+
+func (opts *assumeRoleAuthOptions) ToTokenV3CreateMap(scope map[string]interface{}) (map[string]interface{}, error) {
+	type sessionUser struct {
+		Name string `json:"name"`
+	}
+
+	type assumeRoleReq struct {
+		DomainName      string       `json:"domain_name"`
+		AgencyName      string       `json:"xrole_name"`
+		DurationSeconds int          `json:"duration_seconds,omitempty"`
+		SessionUser     *sessionUser `json:"session_user,omitempty"`
+	}
+
+	type identityReq struct {
+		Methods    []string      `json:"methods"`
+		AssumeRole assumeRoleReq `json:"assume_role"`
+	}
+
+	type authReq struct {
+		Identity identityReq `json:"identity"`
+	}
+
+	var req authReq
+	req.Identity.Methods = []string{"assume_role"}
+	req.Identity.AssumeRole = assumeRoleReq{
+		DomainName:      opts.role.DomainName,
+		AgencyName:      opts.role.AgencyName,
+		DurationSeconds: opts.role.Duration,
+	}
+	if opts.role.SessionName != "" {
+		req.Identity.AssumeRole.SessionUser = &sessionUser{Name: opts.role.SessionName}
+	}
+
+	r, err := golangsdk.BuildRequestBody(req, "auth")
+	if err != nil {
+		return nil, err
+	}
+	r["auth"].(map[string]interface{})["scope"] = scope
+	return r, nil
+}
+
+func (opts *assumeRoleAuthOptions) CanReauth() bool {
+	return false
+}
+
+func (opts *assumeRoleAuthOptions) AuthTokenID() string {
+	return ""
+}
+
+func (opts *assumeRoleAuthOptions) AuthHeaderDomainID() string {
+	return ""
+}
+
+// authenticateClient authenticates the client using given options
+func authenticateClient(client *golangsdk.ProviderClient, ao golangsdk.AuthOptionsProvider) error {
+	if opts, ok := ao.(assumeRoleOptions); ok {
+		return opts.authenticate(client)
+	}
+	return openstack.Authenticate(client, ao)
+}
@@ -0,0 +1,283 @@
+package cfg
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	// RecordEnv is the environment variable with the path of the cassette file
+	// where HTTP interactions are recorded to
+	RecordEnv = "OTC_HTTP_RECORD"
+	// ReplayEnv is the environment variable with the path of the cassette file
+	// which HTTP interactions are replayed from, no requests are sent to the network
+	ReplayEnv = "OTC_HTTP_REPLAY"
+)
+
+type cassetteMode int
+
+const (
+	modeRecord cassetteMode = iota + 1
+	modeReplay
+)
+
+type recordedRequest struct {
+	Method  string   `json:"method"`
+	URL     string   `json:"url"`
+	Headers []string `json:"headers,omitempty"`
+	Body    string   `json:"body,omitempty"`
+}
+
+type recordedResponse struct {
+	StatusCode int      `json:"status_code"`
+	Headers    []string `json:"headers,omitempty"`
+	Body       string   `json:"body,omitempty"`
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+// Cassette records sanitised HTTP interactions to the file or replays them from the file.
+// Cassette file contains one JSON-encoded interaction per line.
+type Cassette struct {
+	mode cassetteMode
+	key  string
+
+	mut  sync.Mutex
+	file *os.File
+	// replay: recorded interactions by request method and URL in the order of recording
+	interactions map[string][]*interaction
+}
+
+// cassettes are shared by path, so the replay position is kept between provider configurations
+// and the recorded file is truncated only once per process
+var (
+	cassettes   = make(map[string]*Cassette)
+	cassetteMut sync.Mutex
+)
+
+// CassetteFromEnv returns cassette configured with `OTC_HTTP_RECORD` or `OTC_HTTP_REPLAY`
+// environment variable. Returns `nil` if none of them is set.
+func CassetteFromEnv() (*Cassette, error) {
+	if path := os.Getenv(ReplayEnv); path != "" {
+		return openCassette(path, modeReplay)
+	}
+	if path := os.Getenv(RecordEnv); path != "" {
+		return openCassette(path, modeRecord)
+	}
+	return nil, nil
+}
+
+func openCassette(path string, mode cassetteMode) (*Cassette, error) {
+	cassetteMut.Lock()
+	defer cassetteMut.Unlock()
+
+	key := fmt.Sprintf("%d:%s", mode, path)
+	if cassette, ok := cassettes[key]; ok {
+		return cassette, nil
+	}
+
+	cassette := &Cassette{mode: mode, key: key}
+	switch mode {
+	case modeRecord:
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("error opening cassette for recording: %s", err)
+		}
+		cassette.file = file
+	case modeReplay:
+		interactions, err := readInteractions(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading cassette for replay: %s", err)
+		}
+		cassette.interactions = interactions
+	}
+	cassettes[key] = cassette
+	return cassette, nil
+}
+
+// CloseCassettes closes all the opened cassettes
+func CloseCassettes() error {
+	cassetteMut.Lock()
+	opened := make([]*Cassette, 0, len(cassettes))
+	for _, cassette := range cassettes {
+		opened = append(opened, cassette)
+	}
+	cassetteMut.Unlock()
+
+	var firstErr error
+	for _, cassette := range opened {
+		if err := cassette.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Close closes the cassette file. Cassette opened for the same path after closing
+// starts recording from the empty file or replaying from the first interaction.
+func (c *Cassette) Close() error {
+	cassetteMut.Lock()
+	if cassettes[c.key] == c {
+		delete(cassettes, c.key)
+	}
+	cassetteMut.Unlock()
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if c.file == nil {
+		return nil
+	}
+	err := c.file.Close()
+	c.file = nil
+	return err
+}
+
+func readInteractions(path string) (map[string][]*interaction, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	interactions := make(map[string][]*interaction)
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			item := new(interaction)
+			if err := json.Unmarshal(line, item); err != nil {
+				return nil, err
+			}
+			key := interactionKey(item.Request.Method, item.Request.URL)
+			interactions[key] = append(interactions[key], item)
+		}
+		if err == io.EOF {
+			return interactions, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func interactionKey(method, url string) string {
+	return method + " " + url
+}
+
+// roundTrip sends the request using given transport and records the interaction,
+// or replays the recorded response without using the transport
+func (c *Cassette) roundTrip(rt http.RoundTripper, request *http.Request, sensitiveKeys []string) (*http.Response, error) {
+	if c.mode == modeReplay {
+		return c.replay(request)
+	}
+	return c.record(rt, request, sensitiveKeys)
+}
+
+func (c *Cassette) replay(request *http.Request) (*http.Response, error) {
+	if request.Body != nil {
+		_, _ = io.Copy(ioutil.Discard, request.Body)
+	}
+
+	c.mut.Lock()
+	key := interactionKey(request.Method, request.URL.String())
+	recorded := c.interactions[key]
+	if len(recorded) == 0 {
+		c.mut.Unlock()
+		return nil, fmt.Errorf("no recorded interaction left for %s", key)
+	}
+	item := recorded[0]
+	c.interactions[key] = recorded[1:]
+	c.mut.Unlock()
+
+	header := make(http.Header)
+	for _, line := range item.Response.Headers {
+		parts := strings.SplitN(line, ": ", 2)
+		if len(parts) == 2 {
+			header.Add(parts[0], parts[1])
+		}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", item.Response.StatusCode, http.StatusText(item.Response.StatusCode)),
+		StatusCode:    item.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(item.Response.Body)),
+		ContentLength: int64(len(item.Response.Body)),
+		Request:       request,
+	}, nil
+}
+
+func (c *Cassette) record(rt http.RoundTripper, request *http.Request, sensitiveKeys []string) (*http.Response, error) {
+	var requestBody []byte
+	if request.Body != nil && request.Body != http.NoBody {
+		body, err := ioutil.ReadAll(request.Body)
+		_ = request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		requestBody = body
+		request.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if request.GetBody == nil {
+			request.GetBody = func() (io.ReadCloser, error) {
+				return ioutil.NopCloser(bytes.NewReader(body)), nil
+			}
+		}
+	}
+
+	response, err := rt.RoundTrip(request)
+	if err != nil {
+		return response, err
+	}
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = ioutil.NopCloser(bytes.NewReader(responseBody))
+
+	item := &interaction{
+		Request: recordedRequest{
+			Method:  request.Method,
+			URL:     request.URL.String(),
+			Headers: redactHeaders(request.Header),
+			Body:    maskBody(requestBody, request.Header.Get("Content-Type"), sensitiveKeys),
+		},
+		Response: recordedResponse{
+			StatusCode: response.StatusCode,
+			Headers:    redactHeaders(response.Header),
+			Body:       maskBody(responseBody, response.Header.Get("Content-Type"), sensitiveKeys),
+		},
+	}
+	if err := c.write(item); err != nil {
+		return nil, fmt.Errorf("error recording HTTP interaction: %s", err)
+	}
+	return response, nil
+}
+
+func (c *Cassette) write(item *interaction) error {
+	line, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if c.file == nil {
+		return fmt.Errorf("cassette is closed")
+	}
+	_, err = c.file.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,93 @@
+package cfg
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// cloudsFile contains cloud settings which are not supported by the SDK config loader
+type cloudsFile struct {
+	Clouds map[string]cloudExtras `yaml:"clouds"`
+}
+
+type cloudExtras struct {
+	Auth struct {
+		SecurityToken     string `yaml:"security_token"`
+		Passcode          string `yaml:"passcode"`
+		CredentialProcess string `yaml:"credential_process"`
+		// agency settings named the same way as the provider arguments
+		AgencyName       string `yaml:"agency_name"`
+		AgencyDomainName string `yaml:"agency_domain_name"`
+		DelegatedProject string `yaml:"delegated_project"`
+	} `yaml:"auth"`
+	// Verify is lost by the SDK loader when it's `false`
+	Verify *bool `yaml:"verify"`
+}
+
+// merge fills empty settings with the fallback ones
+func (e *cloudExtras) merge(fallback *cloudExtras) {
+	setIfEmpty(&e.Auth.SecurityToken, fallback.Auth.SecurityToken)
+	setIfEmpty(&e.Auth.Passcode, fallback.Auth.Passcode)
+	setIfEmpty(&e.Auth.CredentialProcess, fallback.Auth.CredentialProcess)
+	setIfEmpty(&e.Auth.AgencyName, fallback.Auth.AgencyName)
+	setIfEmpty(&e.Auth.AgencyDomainName, fallback.Auth.AgencyDomainName)
+	setIfEmpty(&e.Auth.DelegatedProject, fallback.Auth.DelegatedProject)
+	if e.Verify == nil {
+		e.Verify = fallback.Verify
+	}
+}
+
+// configFiles returns existing `clouds` and `secure` configuration files,
+// they are looked up the same way as the SDK does it
+func configFiles() (cloudsPath, securePath string) {
+	home, _ := os.UserHomeDir()
+	cwd, _ := os.Getwd()
+	dirs := []string{cwd, filepath.Join(home, ".config/openstack"), "/etc/openstack"}
+
+	find := func(env, name string) string {
+		files := []string{os.Getenv(osPrefix + env)}
+		for _, dir := range dirs {
+			for _, suffix := range []string{".yaml", ".yml", ".json"} {
+				files = append(files, filepath.Join(dir, name+suffix))
+			}
+		}
+		for _, file := range files {
+			if file == "" {
+				continue
+			}
+			if _, err := os.Stat(file); err == nil {
+				return file
+			}
+		}
+		return ""
+	}
+	return find("CLIENT_CONFIG_FILE", "clouds"), find("CLIENT_SECURE_FILE", "secure")
+}
+
+// loadCloudExtras reads the extra settings of the cloud from `clouds` file, settings missing there
+// are taken from `secure` file, the same way the SDK merges the files
+func loadCloudExtras(name string) (*cloudExtras, error) {
+	extras := &cloudExtras{}
+	cloudsPath, securePath := configFiles()
+	for _, path := range []string{cloudsPath, securePath} {
+		if path == "" {
+			continue
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		file := &cloudsFile{}
+		// JSON is valid YAML, so both formats are read the same way
+		if err := yaml.Unmarshal(data, file); err != nil {
+			return nil, err
+		}
+		if cloud, ok := file.Clouds[name]; ok {
+			extras.merge(&cloud)
+		}
+	}
+	return extras, nil
+}
@@ -9,18 +9,13 @@ import (
 	"net/http"
 	"os"
 	"strings"
-	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	awsCredentials "github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/credentials/ec2rolecreds"
-	"github.com/aws/aws-sdk-go/aws/defaults"
-	"github.com/aws/aws-sdk-go/aws/ec2metadata"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/go-cleanhttp"
-	"github.com/jinzhu/copier"
 	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/identity/v3/credentials"
@@ -57,7 +52,17 @@ type Config struct {
 	AgencyName       string
 	AgencyDomainName string
 	DelegatedProject string
+	AssumeRoles      []AssumeRole
 	MaxRetries       int
+	RateLimits       []RateLimit
+	DefaultTags      map[string]string
+	IgnoreTags       IgnoreTags
+	Endpoints        map[string]string
+	TraceFile        string
+	SensitiveKeys    []string
+
+	// CredentialProcess is the command printing the credentials
+	CredentialProcess string
 
 	UserAgent string
 
@@ -67,6 +72,22 @@ type Config struct {
 	DomainClient *golangsdk.ProviderClient
 
 	environment *openstack.Env
+
+	limiters *endpointLimiters
+	tracer   *tracer
+	// resource is the Terraform resource the config is scoped to, see ForResource
+	resource tracedResource
+
+	// credentials are temporary AK/SK refreshed before the expiration
+	credentials *temporaryCredentials
+	metadataURL string
+	obsClients  *obsClients
+	// tokenProcess is the credential process printing the token
+	tokenProcess *credentialProcess
+
+	projects *projectConfigs
+	quotas   *quotaTracker
+	lookups  *lookupCache
 }
 
 func (c *Config) LoadAndValidate() error {
@@ -92,27 +113,70 @@ func (c *Config) LoadAndValidate() error {
 		return err
 	}
 
+	// limiters are created before the clients, so they are shared with all the project configs
+	if len(c.RateLimits) != 0 && c.limiters == nil {
+		c.limiters = newEndpointLimiters(c.RateLimits)
+	}
+
+	if err := c.authenticate(); err != nil {
+		return err
+	}
+
+	c.projects = newProjectConfigs()
+	c.quotas = newQuotaTracker()
+	c.lookups = newLookupCache()
+	c.obsClients = newObsClients(c.obsCredentials())
+
+	var osDebug bool
+	if os.Getenv("OS_DEBUG") != "" {
+		osDebug = true
+	}
+	return c.newS3Session(osDebug)
+}
+
+// authenticate builds project and domain provider clients using the configured auth means
+func (c *Config) authenticate() error {
 	var err error
 	switch {
+	case c.CredentialProcess != "":
+		err = c.authenticateByProcess()
 	case c.Token != "":
 		err = buildClientByToken(c)
 	case c.AccessKey != "" && c.SecretKey != "":
+		if c.SecurityToken != "" && c.credentials == nil {
+			c.credentials = newTemporaryCredentials(staticCredentials(c.AccessKey, c.SecretKey, c.SecurityToken))
+		}
 		err = buildClientByAKSK(c)
 	case c.Password != "" && (c.Username != "" || c.UserID != ""):
 		err = buildClientByPassword(c)
 	default:
-		err = errors.New(
-			"no auth means provided. Token, AK/SK or username/password are required for authentication")
+		err = c.authenticateByMetadata()
 	}
 	if err != nil {
 		return fmt.Errorf("failed to authenticate:\n%s", err)
 	}
+	return nil
+}
 
-	var osDebug bool
-	if os.Getenv("OS_DEBUG") != "" {
-		osDebug = true
+// authenticateByMetadata authenticates with temporary AK/SK of the agency attached to the ECS instance
+func (c *Config) authenticateByMetadata() error {
+	metadataURL := c.metadataURL
+	if metadataURL == "" {
+		metadataURL = defaultMetadataURL
 	}
-	return c.newS3Session(osDebug)
+	temporary := newTemporaryCredentials(metadataCredentials(metadataURL))
+	credential, err := temporary.Get()
+	if err != nil {
+		log.Printf("[DEBUG] Temporary credentials are not available: %s", err)
+		return errors.New(
+			"no auth means provided. Token, AK/SK or username/password are required for authentication")
+	}
+	log.Printf("[INFO] Using temporary credentials from the instance metadata")
+	c.credentials = temporary
+	c.AccessKey = credential.AccessKey
+	c.SecretKey = credential.SecretKey
+	c.SecurityToken = credential.SecurityToken
+	return buildClientByAKSK(c)
 }
 
 // setIfEmpty set non-empty `loaded` value to empty `target` variable
@@ -122,7 +186,9 @@ func setIfEmpty(target *string, loaded string) {
 	}
 }
 
-// Load - load existing configuration from config files (`clouds.yaml`, etc.) and env variables
+// Load - load existing configuration from config files (`clouds.yaml`, `secure.yaml`, etc.).
+// Values already set in the config (provider arguments and their environment variables)
+// take precedence over the loaded ones, `secure.yaml` values are used for settings missing in `clouds.yaml`.
 func (c *Config) Load() error {
 	if c.environment == nil {
 		c.environment = openstack.NewEnv(osPrefix)
@@ -131,7 +197,13 @@ func (c *Config) Load() error {
 	if err != nil {
 		return err
 	}
+	extras, err := loadCloudExtras(cloud.Cloud)
+	if err != nil {
+		return fmt.Errorf("error reading clouds configuration: %s", err)
+	}
+
 	// Auth data
+	setIfEmpty(&c.IdentityEndpoint, cloud.AuthInfo.AuthURL)
 	setIfEmpty(&c.Username, cloud.AuthInfo.Username)
 	setIfEmpty(&c.UserID, cloud.AuthInfo.UserID)
 
@@ -155,17 +227,35 @@ func (c *Config) Load() error {
 	// default domain
 	setIfEmpty(&c.DomainID, cloud.AuthInfo.DefaultDomain)
 
-	c.IdentityEndpoint = cloud.AuthInfo.AuthURL
-	c.Token = cloud.AuthInfo.Token
-	c.Password = cloud.AuthInfo.Password
+	// Auth means
+	setIfEmpty(&c.Token, cloud.AuthInfo.Token)
+	setIfEmpty(&c.Password, cloud.AuthInfo.Password)
+	setIfEmpty(&c.Passcode, extras.Auth.Passcode)
+	setIfEmpty(&c.AccessKey, cloud.AuthInfo.AccessKey)
+	setIfEmpty(&c.SecretKey, cloud.AuthInfo.SecretKey)
+	setIfEmpty(&c.SecurityToken, extras.Auth.SecurityToken)
+	setIfEmpty(&c.CredentialProcess, extras.Auth.CredentialProcess)
+
+	// Agency
+	setIfEmpty(&c.AgencyName, extras.Auth.AgencyName)
+	setIfEmpty(&c.AgencyName, cloud.AuthInfo.AgencyName)
+	setIfEmpty(&c.AgencyDomainName, extras.Auth.AgencyDomainName)
+	setIfEmpty(&c.AgencyDomainName, cloud.AuthInfo.AgencyDomainName)
+	setIfEmpty(&c.DelegatedProject, extras.Auth.DelegatedProject)
+	setIfEmpty(&c.DelegatedProject, cloud.AuthInfo.DelegatedProject)
 
 	// General cloud info
 	setIfEmpty(&c.Region, cloud.RegionName)
+	if len(cloud.Regions) != 0 {
+		setIfEmpty(&c.Region, cloud.Regions[0])
+	}
+	setIfEmpty(&c.EndpointType, cloud.EndpointType)
+	setIfEmpty(&c.EndpointType, cloud.Interface)
 	setIfEmpty(&c.CACertFile, cloud.CACertFile)
 	setIfEmpty(&c.ClientCertFile, cloud.ClientCertFile)
 	setIfEmpty(&c.ClientKeyFile, cloud.ClientKeyFile)
-	if cloud.Verify != nil {
-		c.Insecure = !*cloud.Verify
+	if cloud.Verify != nil && !*cloud.Verify || extras.Verify != nil && !*extras.Verify {
+		c.Insecure = true
 	}
 	return nil
 }
@@ -214,6 +304,10 @@ func (c *Config) generateTLSConfig() (*tls.Config, error) {
 // environment in the case that they're not explicitly specified
 // in the Terraform configuration.
 func (c *Config) GetCredentials() (*awsCredentials.Credentials, error) {
+	// temporary credentials are refreshed before the expiration
+	if c.credentials != nil {
+		return awsCredentials.NewCredentials(&awsCredentialsProvider{credentials: c.credentials}), nil
+	}
 	// build a chain provider, lazy-evaluated by aws-sdk
 	providers := []awsCredentials.Provider{
 		&awsCredentials.StaticProvider{Value: awsCredentials.Value{
@@ -228,57 +322,6 @@ func (c *Config) GetCredentials() (*awsCredentials.Credentials, error) {
 		},
 	}
 
-	// Build isolated HTTP client to avoid issues with globally-shared settings
-	client := cleanhttp.DefaultClient()
-
-	// Keep the default timeout (100ms) low as we don't want to wait in non-EC2 environments
-	client.Timeout = 100 * time.Millisecond
-
-	const userTimeoutEnvVar = "AWS_METADATA_TIMEOUT"
-	userTimeout := os.Getenv(userTimeoutEnvVar)
-	if userTimeout != "" {
-		newTimeout, err := time.ParseDuration(userTimeout)
-		if err == nil {
-			if newTimeout.Nanoseconds() > 0 {
-				client.Timeout = newTimeout
-			} else {
-				log.Printf("[WARN] Non-positive value of %s (%s) is meaningless, ignoring", userTimeoutEnvVar, newTimeout.String())
-			}
-		} else {
-			log.Printf("[WARN] Error converting %s to time.Duration: %s", userTimeoutEnvVar, err)
-		}
-	}
-
-	log.Printf("[INFO] Setting AWS metadata API timeout to %s", client.Timeout.String())
-	config := &aws.Config{
-		HTTPClient: client,
-	}
-	usedEndpoint := SetOptionalEndpoint(config)
-
-	// Add the default AWS provider for ECS Task Roles if the relevant env variable is set
-	if uri := os.Getenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI"); len(uri) > 0 {
-		providers = append(providers, defaults.RemoteCredProvider(*config, defaults.Handlers()))
-		log.Print("[INFO] ECS container credentials detected, RemoteCredProvider added to auth chain")
-	}
-
-	// Real AWS should reply to a simple metadata request.
-	// We check it actually does to ensure something else didn't just
-	// happen to be listening on the same IP:Port
-	metadataClient := ec2metadata.New(session.New(config))
-	if metadataClient.Available() {
-		providers = append(providers, &ec2rolecreds.EC2RoleProvider{
-			Client: metadataClient,
-		})
-		log.Print("[INFO] AWS EC2 instance detected via default metadata" +
-			" API endpoint, EC2RoleProvider added to the auth chain")
-	} else {
-		if usedEndpoint == "" {
-			usedEndpoint = "default location"
-		}
-		log.Printf("[INFO] Ignoring AWS metadata API endpoint at %s "+
-			"as it doesn't return any instance-id", usedEndpoint)
-	}
-
 	return awsCredentials.NewChainCredentials(providers), nil
 }
 
@@ -314,6 +357,11 @@ func (c *Config) newS3Session(osDebug bool) error {
 			// S3ForcePathStyle: aws.Bool(c.S3ForcePathStyle),
 		}
 
+		if endpoint := c.Endpoints["obs"]; endpoint != "" {
+			awsConfig.Endpoint = aws.String(endpoint)
+			awsConfig.S3ForcePathStyle = aws.Bool(true)
+		}
+
 		if osDebug {
 			awsConfig.LogLevel = aws.LogLevel(aws.LogDebugWithHTTPBody | aws.LogDebugWithRequestRetries | aws.LogDebugWithRequestErrors)
 			awsConfig.Logger = awsLogger{}
@@ -353,12 +401,20 @@ func (c *Config) validateEndpoint() error {
 
 // validateProject checks that `Project`(`Tenant`) value is set
 func (c *Config) validateProject() error {
-	if c.TenantName == "" && c.TenantID == "" && c.DelegatedProject == "" {
+	if c.TenantName == "" && c.TenantID == "" && c.DelegatedProject == "" && c.assumedProject() == "" {
 		return errors.New("no project name/id or delegated project is provided")
 	}
 	return nil
 }
 
+// assumedProject returns the delegated project of the last assumed agency
+func (c *Config) assumedProject() string {
+	if len(c.AssumeRoles) == 0 {
+		return ""
+	}
+	return c.AssumeRoles[len(c.AssumeRoles)-1].DelegatedProject
+}
+
 func buildClientByToken(c *Config) error {
 	var pao, dao golangsdk.AuthOptions
 
@@ -391,6 +447,9 @@ func buildClientByToken(c *Config) error {
 		ao.IdentityEndpoint = c.IdentityEndpoint
 		ao.TokenID = c.Token
 	}
+	if len(c.AssumeRoles) != 0 {
+		return c.genClients(c.assumeRoleOptions(dao))
+	}
 	return c.genClients(pao, dao)
 }
 
@@ -429,6 +488,9 @@ func buildClientByAKSK(c *Config) error {
 		ao.AccessKey = c.AccessKey
 		ao.SecretKey = c.SecretKey
 	}
+	if len(c.AssumeRoles) != 0 {
+		return c.genClients(c.assumeRoleOptions(dao))
+	}
 	return c.genClients(pao, dao)
 }
 
@@ -471,9 +533,20 @@ func buildClientByPassword(c *Config) error {
 		ao.UserID = c.UserID
 		ao.Passcode = c.Passcode
 	}
+	if len(c.AssumeRoles) != 0 {
+		return c.genClients(c.assumeRoleOptions(dao))
+	}
 	return c.genClients(pao, dao)
 }
 
+// assumeRoleOptions returns project and domain options assuming the configured agency chain,
+// the chain is started with the domain-scoped base options
+func (c *Config) assumeRoleOptions(base golangsdk.AuthOptionsProvider) (pao, dao golangsdk.AuthOptionsProvider) {
+	pao = assumeRoleOptions{base: base, roles: c.AssumeRoles}
+	dao = assumeRoleOptions{base: base, roles: c.AssumeRoles, domainOnly: true}
+	return pao, dao
+}
+
 func (c *Config) genClients(pao, dao golangsdk.AuthOptionsProvider) error {
 	client, err := c.genClient(pao)
 	if err != nil {
@@ -490,6 +563,25 @@ func (c *Config) genClients(pao, dao golangsdk.AuthOptionsProvider) error {
 }
 
 func (c *Config) genClient(ao golangsdk.AuthOptionsProvider) (*golangsdk.ProviderClient, error) {
+	client, err := c.newProviderClient(ao)
+	if err != nil {
+		return nil, err
+	}
+
+	// If using Swift Authentication, there's no need to validate authentication normally.
+	if !c.Swauth {
+		err = authenticateClient(client, ao)
+		if err != nil {
+			return nil, err
+		}
+		c.setupReauth(client, ao)
+	}
+
+	return client, nil
+}
+
+// newProviderClient creates not authenticated provider client with configured transport
+func (c *Config) newProviderClient(ao golangsdk.AuthOptionsProvider) (*golangsdk.ProviderClient, error) {
 	client, err := openstack.NewClient(ao.GetIdentityEndpoint())
 	if err != nil {
 		return nil, err
@@ -502,7 +594,17 @@ func (c *Config) genClient(ao golangsdk.AuthOptionsProvider) (*golangsdk.Provide
 	if err != nil {
 		return nil, err
 	}
-	transport := &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: config}
+	var transport http.RoundTripper = &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: config}
+	if c.credentials != nil {
+		transport = &signingTransport{rt: transport, credentials: c.credentials}
+	}
+	if len(c.RateLimits) != 0 {
+		// limiters are shared between all clients, so the limit is applied to the whole provider
+		if c.limiters == nil {
+			c.limiters = newEndpointLimiters(c.RateLimits)
+		}
+		transport = &rateLimitedTransport{rt: transport, limiters: c.limiters}
+	}
 
 	// if OS_DEBUG is set, log the requests and responses
 	var osDebug bool
@@ -510,11 +612,27 @@ func (c *Config) genClient(ao golangsdk.AuthOptionsProvider) (*golangsdk.Provide
 		osDebug = true
 	}
 
+	cassette, err := CassetteFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	// tracer is shared between all clients, so all exchanges are written to the same file
+	if c.TraceFile != "" && c.tracer == nil {
+		c.tracer, err = openTracer(c.TraceFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	client.HTTPClient = http.Client{
 		Transport: &RoundTripper{
-			Rt:         transport,
-			OsDebug:    osDebug,
-			MaxRetries: c.MaxRetries,
+			Rt:            transport,
+			OsDebug:       osDebug,
+			MaxRetries:    c.MaxRetries,
+			Cassette:      cassette,
+			SensitiveKeys: c.SensitiveKeys,
+			tracer:        c.tracer,
 		},
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			if client.AKSKAuthOptions.AccessKey != "" {
@@ -527,14 +645,6 @@ func (c *Config) genClient(ao golangsdk.AuthOptionsProvider) (*golangsdk.Provide
 		},
 	}
 
-	// If using Swift Authentication, there's no need to validate authentication normally.
-	if !c.Swauth {
-		err = openstack.Authenticate(client, ao)
-		if err != nil {
-			return nil, err
-		}
-	}
-
 	return client, nil
 }
 
@@ -573,6 +683,10 @@ func (c *Config) S3Client(region string) (*s3.S3, error) {
 	if err != nil {
 		return nil, err
 	}
+	client, err = c.overrideEndpoint("obs", client)
+	if err != nil {
+		return nil, err
+	}
 
 	awsS3Sess := c.s3sess.Copy(&aws.Config{Endpoint: aws.String(client.Endpoint)})
 	s3conn := s3.New(awsS3Sess)
@@ -593,6 +707,9 @@ func setUpOBSLogging() {
 
 // issueTemporaryCredentials creates temporary AK/SK, which can be used to auth in OBS when AK/SK is not provided
 func (c *Config) issueTemporaryCredentials() (*credentials.TemporaryCredential, error) {
+	if c.credentials != nil {
+		return c.credentials.Get()
+	}
 	if c.AccessKey != "" && c.SecretKey != "" {
 		return &credentials.TemporaryCredential{
 			AccessKey:     c.AccessKey,
@@ -615,11 +732,6 @@ func (c *Config) issueTemporaryCredentials() (*credentials.TemporaryCredential,
 }
 
 func (c *Config) NewObjectStorageClient(region string) (*obs.ObsClient, error) {
-	cred, err := c.issueTemporaryCredentials()
-	if err != nil {
-		return nil, fmt.Errorf("failed to construct OBS client without AK/SK: %s", err)
-	}
-
 	client, err := openstack.NewOBSService(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getEndpointType(),
@@ -627,65 +739,117 @@ func (c *Config) NewObjectStorageClient(region string) (*obs.ObsClient, error) {
 	if err != nil {
 		return nil, err
 	}
+	client, err = c.overrideEndpoint("obs", client)
+	if err != nil {
+		return nil, err
+	}
 
 	setUpOBSLogging()
 
-	return obs.New(cred.AccessKey, cred.SecretKey, client.Endpoint, obs.WithSecurityToken(cred.SecurityToken))
+	clients := c.obsClients
+	if clients == nil {
+		clients = newObsClients(c.obsCredentials())
+	}
+	obsClient, err := clients.get(client.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct OBS client without AK/SK: %s", err)
+	}
+	return obsClient, nil
+}
+
+// obsCredentials returns temporary credentials shared by all OBS clients
+func (c *Config) obsCredentials() *temporaryCredentials {
+	if c.credentials != nil {
+		return c.credentials
+	}
+	return newTemporaryCredentials(c.issueTemporaryCredentials)
 }
 
 func (c *Config) blockStorageV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewBlockStorageV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewBlockStorageV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("evs", client)
 }
 
 func (c *Config) BlockStorageV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewBlockStorageV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewBlockStorageV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("evs", client)
 }
 
 func (c *Config) BlockStorageV3Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewBlockStorageV3(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewBlockStorageV3(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("evs", client)
 }
 
 func (c *Config) CbrV3Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewCBRService(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewCBRService(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("cbr", client)
 }
 
 func (c *Config) ComputeV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewComputeV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewComputeV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("ecs", client)
 }
 
 func (c *Config) ComputeV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewComputeV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewComputeV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("ecs", client)
 }
 
 func (c *Config) DnsV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewDNSV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewDNSV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("dns", client)
 }
 
 func (c *Config) IdentityV3Client(_ ...string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewIdentityV3(c.DomainClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewIdentityV3(c.DomainClient, golangsdk.EndpointOpts{
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("iam", client)
 }
 
 // IdentityV30Client - provides client is used for use with endpoints with invalid "v3.0" URLs
@@ -697,53 +861,77 @@ func (c *Config) IdentityV30Client() (*golangsdk.ServiceClient, error) {
 		return nil, err
 	}
 	service.Endpoint = strings.Replace(service.IdentityEndpoint, "v3/", "v3.0/", 1)
-	return service, nil
+	return c.overrideEndpoint("iam", service)
 }
 
 func (c *Config) ImageV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewImageServiceV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewImageServiceV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("ims", client)
 }
 
 func (c *Config) ImageV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewImageServiceV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewImageServiceV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("ims", client)
 }
 
 func (c *Config) NetworkingV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewNetworkV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewNetworkV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("vpc", client)
 }
 
 func (c *Config) NetworkingV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewNetworkV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewNetworkV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("vpc", client)
 }
 
 func (c *Config) SmnV2Client(projectName ProjectName) (*golangsdk.ServiceClient, error) {
-	newConfig, err := reconfigProjectName(*c, projectName)
+	newConfig, err := c.ForProject(projectName)
 	if err != nil {
 		return nil, err
 	}
-	return openstack.NewSMNV2(newConfig.HwClient, golangsdk.EndpointOpts{
-		Region:       c.GetRegion(nil),
-		Availability: c.getEndpointType(),
+	client, err := openstack.NewSMNV2(newConfig.HwClient, golangsdk.EndpointOpts{
+		Region:       newConfig.GetRegion(nil),
+		Availability: newConfig.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return newConfig.overrideEndpoint("smn", client)
 }
 
 func (c *Config) CesV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewCESClient(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewCESClient(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("ces", client)
 }
 
 func (c *Config) getEndpointType() golangsdk.Availability {
@@ -757,133 +945,205 @@ func (c *Config) getEndpointType() golangsdk.Availability {
 }
 
 func (c *Config) KmsKeyV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewKMSV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewKMSV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("kms", client)
 }
 
 func (c *Config) NatV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewNatV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewNatV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("nat", client)
 }
 
 func (c *Config) OrchestrationV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewOrchestrationV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewOrchestrationV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("rts", client)
 }
 
 func (c *Config) SfsV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewSharedFileSystemV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewSharedFileSystemV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("sfs", client)
 }
 
 func (c *Config) SfsTurboV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewSharedFileSystemTurboV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewSharedFileSystemTurboV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("sfs_turbo", client)
 }
 
 func (c *Config) VbsV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewVBS(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewVBS(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("vbs", client)
 }
 
 func (c *Config) AutoscalingV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewAutoScalingV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewAutoScalingV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("as", client)
 }
 
 func (c *Config) AutoscalingV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewAutoScalingV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewAutoScalingV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("as", client)
 }
 
 func (c *Config) CsbsV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewCSBSService(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewCSBSService(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("csbs", client)
 }
 
 func (c *Config) DehV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewDeHServiceV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewDeHServiceV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("deh", client)
 }
 
 func (c *Config) DmsV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewDMSServiceV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewDMSServiceV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("dms", client)
 }
 
 func (c *Config) MrsV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewMapReduceV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewMapReduceV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("mrs", client)
 }
 
 func (c *Config) ElbV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewELBV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewELBV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("elb", client)
 }
 
 func (c *Config) RdsV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewRDSV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewRDSV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("rds", client)
 }
 
 func (c *Config) AntiddosV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewAntiDDoSV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewAntiDDoSV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("antiddos", client)
 }
 
 func (c *Config) CtsV1Client(projectName ProjectName) (*golangsdk.ServiceClient, error) {
-	newConfig, err := reconfigProjectName(*c, projectName)
+	newConfig, err := c.ForProject(projectName)
 	if err != nil {
 		return nil, err
 	}
-	return openstack.NewCTSService(newConfig.HwClient, golangsdk.EndpointOpts{
-		Region:       c.GetRegion(nil),
-		Availability: c.getEndpointType(),
+	client, err := openstack.NewCTSService(newConfig.HwClient, golangsdk.EndpointOpts{
+		Region:       newConfig.GetRegion(nil),
+		Availability: newConfig.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return newConfig.overrideEndpoint("cts", client)
 }
 
 func (c *Config) CssV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewCSSService(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewCSSService(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("css", client)
 }
 
 func (c *Config) CceV3Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewCCE(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewCCE(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("cce", client)
 }
 
 func (c *Config) CceV3AddonClient(region string) (*golangsdk.ServiceClient, error) {
@@ -896,71 +1156,91 @@ func (c *Config) CceV3AddonClient(region string) (*golangsdk.ServiceClient, erro
 }
 
 func (c *Config) DcsV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewDCSServiceV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewDCSServiceV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("dcs", client)
 }
 
 func (c *Config) RdsTagV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewRdsTagV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewRdsTagV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("rds", client)
 }
 
 func (c *Config) WafV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewWAFV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewWAFV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("waf", client)
 }
 
 func (c *Config) RdsV3Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewRDSV3(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewRDSV3(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("rds", client)
 }
 
 func (c *Config) SdrsV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewSDRSV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewSDRSV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("sdrs", client)
 }
 
 func (c *Config) LtsV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewLTSV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewLTSV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("lts", client)
 }
 
 func (c *Config) DdsV3Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewDDSServiceV3(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewDDSServiceV3(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.overrideEndpoint("dds", client)
 }
 
 func (c *Config) SwrV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return openstack.NewSWRV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := openstack.NewSWRV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       region,
 		Availability: c.getEndpointType(),
 	})
-}
-
-func reconfigProjectName(src Config, projectName ProjectName) (*Config, error) {
-	config := &Config{}
-	if err := copier.Copy(config, &src); err != nil {
-		return nil, err
-	}
-	config.TenantName = string(projectName)
-	if err := config.LoadAndValidate(); err != nil {
+	if err != nil {
 		return nil, err
 	}
-	return config, nil
+	return c.overrideEndpoint("swr", client)
 }
 
 type SchemaOrDiff interface {
@@ -997,15 +1277,8 @@ func (c *Config) GetProjectName(d SchemaOrDiff) ProjectName {
 	if tenantName == "" {
 		tenantName = c.DelegatedProject
 	}
-	return ProjectName(tenantName)
-}
-
-func SetOptionalEndpoint(cfg *aws.Config) string {
-	endpoint := os.Getenv("AWS_METADATA_URL")
-	if endpoint != "" {
-		log.Printf("[INFO] Setting custom metadata endpoint: %q", endpoint)
-		cfg.Endpoint = aws.String(endpoint)
-		return endpoint
+	if tenantName == "" {
+		tenantName = c.assumedProject()
 	}
-	return ""
+	return ProjectName(tenantName)
 }
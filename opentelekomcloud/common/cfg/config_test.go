@@ -1,17 +1,24 @@
 package cfg
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"text/template"
+	"time"
 
 	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/identity/v3/credentials"
 	th "github.com/opentelekomcloud/gophertelekomcloud/testhelper"
 )
 
@@ -199,3 +206,919 @@ func TestRequestRetry(t *testing.T) {
 	t.Run("TestRequestSingleRetry", func(t *testing.T) { testRequestRetry(t, 1) })
 	t.Run("TestRequestZeroRetry", func(t *testing.T) { testRequestRetry(t, 0) })
 }
+
+func TestForProjectCache(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var info = struct {
+		scopes map[string]int
+		mut    *sync.Mutex
+	}{
+		map[string]int{},
+		new(sync.Mutex),
+	}
+
+	th.Mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Auth struct {
+				Scope struct {
+					Project struct {
+						Name string `json:"name"`
+					} `json:"project"`
+				} `json:"scope"`
+			} `json:"auth"`
+		}
+		th.AssertNoErr(t, json.NewDecoder(r.Body).Decode(&body))
+		info.mut.Lock()
+		info.scopes[body.Auth.Scope.Project.Name]++
+		info.mut.Unlock()
+
+		w.Header().Set("X-Subject-Token", "token")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, tokenOutput)
+	})
+
+	config := &Config{
+		IdentityEndpoint: th.Endpoint() + "v3",
+		Username:         "user",
+		Password:         "qwerty!",
+		DomainName:       "DOMAIN001",
+		TenantName:       "eu-de",
+		Region:           "eu-de",
+		RateLimits:       []RateLimit{{Service: "vpc", RequestsPerSecond: 10}},
+	}
+	th.AssertNoErr(t, config.LoadAndValidate())
+
+	sameProject, err := config.ForProject("eu-de")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, config, sameProject)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			projectConfig, err := config.ForProject("eu-nl_project")
+			th.AssertNoErr(t, err)
+			th.AssertEquals(t, "eu-nl_project", projectConfig.TenantName)
+			th.AssertEquals(t, "eu-nl", projectConfig.GetRegion(nil))
+			// rate limits are shared with the project configs
+			th.AssertEquals(t, config.limiters, projectConfig.limiters)
+		}()
+	}
+	wg.Wait()
+
+	// one project-scoped and one domain-scoped authentication per project
+	th.AssertEquals(t, 1, info.scopes["eu-de"])
+	th.AssertEquals(t, 1, info.scopes["eu-nl_project"])
+	th.AssertEquals(t, 2, info.scopes[""])
+	th.AssertEquals(t, "eu-de", config.TenantName)
+	th.AssertEquals(t, "eu-de", config.GetRegion(nil))
+}
+
+type identityStub struct {
+	mut    *sync.Mutex
+	issued int
+	valid  string
+}
+
+func (s *identityStub) issueToken(w http.ResponseWriter, _ *http.Request) {
+	s.mut.Lock()
+	s.issued++
+	s.valid = fmt.Sprintf("token-%d", s.issued)
+	token := s.valid
+	s.mut.Unlock()
+
+	w.Header().Set("X-Subject-Token", token)
+	w.WriteHeader(http.StatusCreated)
+	_, _ = fmt.Fprint(w, tokenOutput)
+}
+
+func (s *identityStub) expire() {
+	s.mut.Lock()
+	s.valid = "expired"
+	s.mut.Unlock()
+}
+
+func (s *identityStub) serveResource(w http.ResponseWriter, r *http.Request) {
+	s.mut.Lock()
+	valid := s.valid
+	s.mut.Unlock()
+
+	if r.Header.Get("X-Auth-Token") != valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = fmt.Fprint(w, `{}`)
+}
+
+func TestReauthOnExpiredToken(t *testing.T) {
+	cases := map[string]golangsdk.AuthOptions{
+		"password": {
+			Username:   "user",
+			Password:   "qwerty!",
+			DomainName: "DOMAIN001",
+		},
+		"agency": {
+			Username:         "user",
+			Password:         "qwerty!",
+			DomainName:       "DOMAIN001",
+			AgencyName:       "agency",
+			AgencyDomainName: "DOMAIN002",
+			DelegatedProject: "eu-de_project",
+		},
+	}
+	for name, opts := range cases {
+		t.Run(name, func(t *testing.T) {
+			th.SetupHTTP()
+			defer th.TeardownHTTP()
+
+			stub := &identityStub{mut: new(sync.Mutex)}
+			th.Mux.HandleFunc("/v3/auth/tokens", stub.issueToken)
+			th.Mux.HandleFunc("/resource", stub.serveResource)
+
+			opts.IdentityEndpoint = th.Endpoint() + "v3"
+			client, err := (&Config{}).genClient(opts)
+			th.AssertNoErr(t, err)
+			issuedOnAuth := stub.issued
+
+			stub.expire()
+
+			wg := sync.WaitGroup{}
+			for i := 0; i < 5; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := client.Request("GET", th.Endpoint()+"resource", &golangsdk.RequestOpts{})
+					th.AssertNoErr(t, err)
+				}()
+			}
+			wg.Wait()
+
+			// all the requests failed with the same token cause single re-authentication
+			th.AssertEquals(t, 2*issuedOnAuth, stub.issued)
+			th.AssertEquals(t, stub.valid, client.Token())
+		})
+	}
+}
+
+func TestCanReauth(t *testing.T) {
+	th.AssertEquals(t, false, canReauth(golangsdk.AuthOptions{TokenID: "token"}))
+	th.AssertEquals(t, false, canReauth(golangsdk.AKSKAuthOptions{AccessKey: "ak", SecretKey: "sk"}))
+	th.AssertEquals(t, true, canReauth(golangsdk.AKSKAuthOptions{
+		AccessKey:        "ak",
+		SecretKey:        "sk",
+		AgencyName:       "agency",
+		AgencyDomainName: "DOMAIN002",
+	}))
+	th.AssertEquals(t, true, canReauth(assumeRoleOptions{
+		base:  golangsdk.AuthOptions{TokenID: "token"},
+		roles: []AssumeRole{{AgencyName: "agency", DomainName: "DOMAIN002"}},
+	}))
+}
+
+func TestIgnoreTags(t *testing.T) {
+	ignore := IgnoreTags{
+		Keys:        []string{"owner"},
+		KeyPrefixes: []string{"cmdb:"},
+	}
+	th.AssertEquals(t, true, ignore.Ignored("owner"))
+	th.AssertEquals(t, true, ignore.Ignored("cmdb:id"))
+	th.AssertEquals(t, false, ignore.Ignored("owner-team"))
+	th.AssertEquals(t, false, ignore.Ignored("name"))
+	th.AssertEquals(t, false, IgnoreTags{}.Ignored("owner"))
+}
+
+func TestEndpointOverride(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/vpcs/vpc-id", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"vpc": {"id": "vpc-id", "name": "mocked"}}`)
+	})
+
+	client := &golangsdk.ProviderClient{
+		HTTPClient: http.Client{},
+		EndpointLocator: func(opts golangsdk.EndpointOpts) (string, error) {
+			return "https://vpc.eu-de.otc.t-systems.com/", nil
+		},
+	}
+	config := &Config{
+		HwClient:  client,
+		Endpoints: map[string]string{"vpc": th.Endpoint()},
+	}
+
+	vpcClient, err := config.NetworkingV1Client("eu-de")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, th.Endpoint(), vpcClient.Endpoint)
+	th.AssertEquals(t, th.Endpoint()+"v1/", vpcClient.ResourceBase)
+
+	resp, err := vpcClient.Get(vpcClient.ServiceURL("vpcs", "vpc-id"), nil, nil)
+	th.AssertNoErr(t, err)
+	_ = resp.Body.Close()
+
+	ecsClient, err := config.ComputeV1Client("eu-de")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://vpc.eu-de.otc.t-systems.com/", ecsClient.Endpoint)
+}
+
+func TestReplaceEndpointBase(t *testing.T) {
+	cases := []struct {
+		endpoint string
+		base     string
+		expected string
+	}{
+		{"https://rds.eu-de.otc.t-systems.com/v3/123/", "http://localhost:8080", "http://localhost:8080/v3/123/"},
+		{"https://rds.eu-de.otc.t-systems.com/v3/123/", "http://localhost:8080/", "http://localhost:8080/v3/123/"},
+		{"https://rds.eu-de.otc.t-systems.com/v3/123/", "https://proxy.example.com/rds/", "https://proxy.example.com/rds/v3/123/"},
+		{"https://obs.eu-de.otc.t-systems.com", "https://obs.private.example.com", "https://obs.private.example.com/"},
+	}
+	for _, c := range cases {
+		actual, err := replaceEndpointBase(c.endpoint, c.base)
+		th.AssertNoErr(t, err)
+		th.AssertEquals(t, c.expected, actual)
+	}
+
+	_, err := replaceEndpointBase("https://rds.eu-de.otc.t-systems.com/v3/123/", "localhost:8080")
+	if err == nil {
+		t.Fatal("error expected for relative endpoint")
+	}
+}
+
+type metadataStub struct {
+	mut    sync.Mutex
+	issued int
+}
+
+func (s *metadataStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/openstack/latest/securitykey" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	s.mut.Lock()
+	s.issued++
+	issued := s.issued
+	s.mut.Unlock()
+
+	expiresAt := time.Now().Add(time.Hour).UTC().Format("2006-01-02T15:04:05.000000Z")
+	_, _ = fmt.Fprintf(w, `{"credential": {"access": "ak-%[1]d", "secret": "sk-%[1]d", "securitytoken": "token-%[1]d", "expires_at": "%[2]s"}}`,
+		issued, expiresAt)
+}
+
+func (s *metadataStub) current() int {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	return s.issued
+}
+
+func TestMetadataCredentials(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	metadata := &metadataStub{}
+	metadataServer := httptest.NewServer(metadata)
+	defer metadataServer.Close()
+
+	th.Mux.HandleFunc("/v3/projects", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"projects": [{"id": "project-id", "name": "eu-de"}], "links": {}}`)
+	})
+	th.Mux.HandleFunc("/v3/auth/catalog", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"catalog": []}`)
+	})
+	th.Mux.HandleFunc("/resource", func(w http.ResponseWriter, r *http.Request) {
+		issued := metadata.current()
+		if !strings.Contains(r.Header.Get("Authorization"), fmt.Sprintf("Credential=ak-%d/", issued)) ||
+			r.Header.Get("X-Security-Token") != fmt.Sprintf("token-%d", issued) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+
+	config := &Config{
+		IdentityEndpoint: th.Endpoint() + "v3",
+		TenantName:       "eu-de",
+		metadataURL:      metadataServer.URL,
+	}
+	th.AssertNoErr(t, config.authenticate())
+	th.AssertEquals(t, 1, metadata.current())
+	th.AssertEquals(t, "ak-1", config.AccessKey)
+
+	_, err := config.HwClient.Request("GET", th.Endpoint()+"resource", &golangsdk.RequestOpts{})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, metadata.current())
+
+	// credentials expiring soon are refreshed and used by the existing client
+	defaultRefresh := refreshBeforeExpiry
+	refreshBeforeExpiry = 2 * time.Hour
+	defer func() { refreshBeforeExpiry = defaultRefresh }()
+
+	_, err = config.HwClient.Request("GET", th.Endpoint()+"resource", &golangsdk.RequestOpts{})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, metadata.current())
+
+	credential, err := config.issueTemporaryCredentials()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "ak-3", credential.AccessKey)
+
+	awsCreds, err := config.GetCredentials()
+	th.AssertNoErr(t, err)
+	value, err := awsCreds.Get()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "token-4", value.SessionToken)
+}
+
+func TestMetadataCredentialsUnavailable(t *testing.T) {
+	metadataServer := httptest.NewServer(http.NotFoundHandler())
+	defer metadataServer.Close()
+
+	config := &Config{
+		IdentityEndpoint: "http://localhost/v3",
+		metadataURL:      metadataServer.URL,
+	}
+	err := config.authenticate()
+	if err == nil || !strings.Contains(err.Error(), "no auth means provided") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestObsClientsRefresh(t *testing.T) {
+	var issued int
+	source := func() (*credentials.TemporaryCredential, error) {
+		issued++
+		return &credentials.TemporaryCredential{
+			AccessKey:     fmt.Sprintf("ak-%d", issued),
+			SecretKey:     fmt.Sprintf("sk-%d", issued),
+			SecurityToken: fmt.Sprintf("token-%d", issued),
+			ExpiresAt:     time.Now().Add(time.Hour).Format(time.RFC3339Nano),
+		}, nil
+	}
+
+	tokens := make(chan string, 1)
+	obsServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("X-Amz-Security-Token")
+		if token == "" {
+			token = r.Header.Get("X-Obs-Security-Token")
+		}
+		tokens <- token
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer obsServer.Close()
+
+	clients := newObsClients(newTemporaryCredentials(source))
+	client, err := clients.get(obsServer.URL)
+	th.AssertNoErr(t, err)
+	_, _ = client.ListBuckets(nil)
+	th.AssertEquals(t, "token-1", <-tokens)
+
+	// clients are shared until the credentials expire soon
+	sameClient, err := clients.get(obsServer.URL)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, client, sameClient)
+	_, _ = sameClient.ListBuckets(nil)
+	th.AssertEquals(t, "token-1", <-tokens)
+	th.AssertEquals(t, 1, issued)
+
+	defaultRefresh := refreshBeforeExpiry
+	refreshBeforeExpiry = 2 * time.Hour
+	defer func() { refreshBeforeExpiry = defaultRefresh }()
+
+	refreshed, err := clients.get(obsServer.URL)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, client, refreshed)
+	_, _ = refreshed.ListBuckets(nil)
+	th.AssertEquals(t, "token-2", <-tokens)
+	th.AssertEquals(t, 2, issued)
+
+	// existing client uses refreshed credentials
+	_, _ = client.ListBuckets(nil)
+	th.AssertEquals(t, "token-2", <-tokens)
+
+	// client for the other endpoint renews the credentials of the existing clients too
+	otherServer := httptest.NewServer(obsServer.Config.Handler)
+	defer otherServer.Close()
+	other, err := clients.get(otherServer.URL)
+	th.AssertNoErr(t, err)
+	_, _ = other.ListBuckets(nil)
+	th.AssertEquals(t, "token-3", <-tokens)
+	_, _ = client.ListBuckets(nil)
+	th.AssertEquals(t, "token-3", <-tokens)
+}
+
+func TestNewObjectStorageClientNotLoaded(t *testing.T) {
+	config := &Config{
+		AccessKey: "ak",
+		SecretKey: "sk",
+		HwClient: &golangsdk.ProviderClient{
+			EndpointLocator: func(opts golangsdk.EndpointOpts) (string, error) {
+				return "https://obs.eu-de.otc.t-systems.com/", nil
+			},
+		},
+	}
+	client, err := config.NewObjectStorageClient("eu-de")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, client != nil)
+}
+
+func TestAssumeRoleChain(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	type tokenRequest struct {
+		authToken string
+		body      map[string]interface{}
+	}
+	var requests []tokenRequest
+	stub := &identityStub{mut: new(sync.Mutex)}
+	th.Mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		th.AssertNoErr(t, json.NewDecoder(r.Body).Decode(&body))
+		stub.mut.Lock()
+		requests = append(requests, tokenRequest{authToken: r.Header.Get("X-Auth-Token"), body: body})
+		stub.mut.Unlock()
+		stub.issueToken(w, r)
+	})
+
+	config := &Config{
+		IdentityEndpoint: th.Endpoint() + "v3",
+		Username:         "user",
+		Password:         "qwerty!",
+		DomainName:       "DOMAIN001",
+		AssumeRoles: []AssumeRole{
+			{
+				AgencyName: "security",
+				DomainName: "DOMAIN002",
+			},
+			{
+				AgencyName:       "workload",
+				DomainName:       "DOMAIN003",
+				DelegatedProject: "eu-de_workload",
+				Duration:         3600,
+				SessionName:      "ci-pipeline",
+			},
+		},
+	}
+	th.AssertNoErr(t, config.validateProject())
+	th.AssertNoErr(t, buildClientByPassword(config))
+	th.AssertEquals(t, ProjectName("eu-de_workload"), config.GetProjectName(nil))
+
+	// 3 requests for each of the project and domain clients
+	th.AssertEquals(t, 6, len(requests))
+	th.AssertEquals(t, "", requests[0].authToken)
+	th.AssertJSONEquals(t, `{
+		"methods": ["assume_role"],
+		"assume_role": {"domain_name": "DOMAIN002", "xrole_name": "security"}
+	}`, requests[1].body["auth"].(map[string]interface{})["identity"])
+	th.AssertEquals(t, "token-1", requests[1].authToken)
+	th.AssertJSONEquals(t, `{"domain": {"name": "DOMAIN002"}}`, requests[1].body["auth"].(map[string]interface{})["scope"])
+
+	th.AssertEquals(t, "token-2", requests[2].authToken)
+	th.AssertJSONEquals(t, `{
+		"methods": ["assume_role"],
+		"assume_role": {
+			"domain_name": "DOMAIN003",
+			"xrole_name": "workload",
+			"duration_seconds": 3600,
+			"session_user": {"name": "ci-pipeline"}
+		}
+	}`, requests[2].body["auth"].(map[string]interface{})["identity"])
+	th.AssertJSONEquals(t, `{"project": {"name": "eu-de_workload", "domain": {"name": "DOMAIN003"}}}`, requests[2].body["auth"].(map[string]interface{})["scope"])
+	th.AssertEquals(t, "token-3", config.HwClient.Token())
+
+	// domain client is scoped to the domain of the last agency
+	th.AssertJSONEquals(t, `{"domain": {"name": "DOMAIN003"}}`, requests[5].body["auth"].(map[string]interface{})["scope"])
+	th.AssertEquals(t, "token-6", config.DomainClient.Token())
+
+	// expired token is renewed by assuming the whole chain again
+	stub.expire()
+	th.Mux.HandleFunc("/resource", stub.serveResource)
+	_, err := config.HwClient.Request("GET", th.Endpoint()+"resource", &golangsdk.RequestOpts{})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 9, len(requests))
+	th.AssertEquals(t, "token-9", config.HwClient.Token())
+}
+
+func TestForProjectAssumeRole(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var scopes []interface{}
+	stub := &identityStub{mut: new(sync.Mutex)}
+	th.Mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		th.AssertNoErr(t, json.NewDecoder(r.Body).Decode(&body))
+		stub.mut.Lock()
+		scopes = append(scopes, body["auth"].(map[string]interface{})["scope"])
+		stub.mut.Unlock()
+		stub.issueToken(w, r)
+	})
+
+	config := &Config{
+		IdentityEndpoint: th.Endpoint() + "v3",
+		Username:         "user",
+		Password:         "qwerty!",
+		DomainName:       "DOMAIN001",
+		TenantName:       "eu-de",
+		Region:           "eu-de",
+		AssumeRoles: []AssumeRole{
+			{AgencyName: "security", DomainName: "DOMAIN002"},
+			{AgencyName: "workload", DomainName: "DOMAIN003", DelegatedProject: "eu-de_workload"},
+		},
+	}
+	th.AssertNoErr(t, buildClientByPassword(config))
+	th.AssertEquals(t, 6, len(scopes))
+
+	projectConfig, err := config.ForProject("eu-nl_workload")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, ProjectName("eu-nl_workload"), projectConfig.GetProjectName(nil))
+	th.AssertEquals(t, "eu-nl", projectConfig.GetRegion(nil))
+
+	// project token is issued by the last agency of the chain for the requested project
+	th.AssertEquals(t, 12, len(scopes))
+	th.AssertJSONEquals(t, `{"project": {"name": "eu-nl_workload", "domain": {"name": "DOMAIN003"}}}`, scopes[8])
+	th.AssertJSONEquals(t, `{"domain": {"name": "DOMAIN003"}}`, scopes[11])
+
+	// source config chain is not modified
+	th.AssertEquals(t, "eu-de_workload", config.AssumeRoles[1].DelegatedProject)
+	th.AssertEquals(t, ProjectName("eu-de"), config.GetProjectName(nil))
+}
+
+// writeCredentialProcess writes the script printing given output and counting its runs
+func writeCredentialProcess(t *testing.T, dir, output string) (command string, runs func() int) {
+	outputFile := filepath.Join(dir, "output.json")
+	th.AssertNoErr(t, ioutil.WriteFile(outputFile, []byte(output), 0600))
+	countFile := filepath.Join(dir, "count")
+	command = fmt.Sprintf("echo run >> %s && cat %s", countFile, outputFile)
+	runs = func() int {
+		data, _ := ioutil.ReadFile(countFile)
+		return strings.Count(string(data), "run")
+	}
+	return
+}
+
+func TestCredentialProcess(t *testing.T) {
+	dir, err := ioutil.TempDir("", "credential-process")
+	th.AssertNoErr(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	expiresAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+	command, runs := writeCredentialProcess(t, dir, fmt.Sprintf(
+		`{"access_key": "ak", "secret_key": "sk", "security_token": "token", "expires_at": "%s"}`, expiresAt))
+
+	process := newCredentialProcess(command)
+	credential, err := process.credentials()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "ak", credential.AccessKey)
+	th.AssertEquals(t, "sk", credential.SecretKey)
+	th.AssertEquals(t, "token", credential.SecurityToken)
+	th.AssertEquals(t, expiresAt, credential.ExpiresAt)
+
+	_, err = process.Get()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, runs())
+
+	// process is re-run when the credentials expire soon
+	defaultRefreshBefore := refreshBeforeExpiry
+	refreshBeforeExpiry = 2 * time.Hour
+	defer func() { refreshBeforeExpiry = defaultRefreshBefore }()
+
+	_, err = process.Get()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, runs())
+
+	_, err = process.token()
+	th.AssertEquals(t, "credential process output contains no token", err.Error())
+
+	_, err = newCredentialProcess("echo '{}'").Get()
+	th.AssertEquals(t, "error running credential process: output contains neither token nor AK/SK", err.Error())
+
+	_, err = newCredentialProcess("echo failed >&2 && exit 3").Get()
+	th.AssertEquals(t, "error running credential process: exit status 3: failed", err.Error())
+}
+
+func TestCredentialProcessToken(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		w.Header().Set("X-Subject-Token", r.Header.Get("X-Subject-Token"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, tokenOutput)
+	})
+
+	dir, err := ioutil.TempDir("", "credential-process")
+	th.AssertNoErr(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	command, runs := writeCredentialProcess(t, dir, `{"token": "process-token"}`)
+
+	config := &Config{
+		IdentityEndpoint:  th.Endpoint() + "v3",
+		TenantName:        "eu-de",
+		CredentialProcess: command,
+		// credential process wins over other auth means
+		Username: "user",
+		Password: "qwerty!",
+	}
+	th.AssertNoErr(t, config.authenticate())
+	th.AssertEquals(t, "process-token", config.HwClient.Token())
+	th.AssertEquals(t, "process-token", config.DomainClient.Token())
+	th.AssertEquals(t, 1, runs())
+
+	// expired token is replaced with the one printed by the process
+	th.AssertNoErr(t, config.HwClient.ReauthFunc())
+	th.AssertEquals(t, 2, runs())
+}
+
+func TestCredentialProcessCloudsYaml(t *testing.T) {
+	cloudsYaml := `
+clouds:
+  otc-process:
+    auth:
+      auth_url: https://iam.eu-de.otc.t-systems.com/v3
+      project_name: eu-de
+      credential_process: vault-otc-credentials --role ci
+  otc-aksk:
+    auth:
+      auth_url: https://iam.eu-de.otc.t-systems.com/v3
+      project_name: eu-de
+      ak: access-key
+      sk: secret-key
+`
+	th.AssertNoErr(t, ioutil.WriteFile(fileName, []byte(cloudsYaml), 0600))
+	defer func() { _ = os.Remove(fileName) }()
+
+	config := &Config{Cloud: "otc-process", environment: openstack.NewEnv(osPrefix, false)}
+	th.AssertNoErr(t, config.Load())
+	th.AssertEquals(t, "vault-otc-credentials --role ci", config.CredentialProcess)
+
+	config = &Config{Cloud: "otc-aksk", environment: openstack.NewEnv(osPrefix, false)}
+	th.AssertNoErr(t, config.Load())
+	th.AssertEquals(t, "access-key", config.AccessKey)
+	th.AssertEquals(t, "secret-key", config.SecretKey)
+	th.AssertEquals(t, "", config.CredentialProcess)
+}
+
+const secureFileName = "./secure.yaml"
+
+func TestLoadPrecedence(t *testing.T) {
+	cloudsYaml := `
+clouds:
+  otc-full:
+    auth:
+      auth_url: https://iam.eu-de.otc.t-systems.com/v3
+      username: user
+      project_name: eu-de_project
+      domain_name: OTC001
+      ak: clouds-ak
+      passcode: "123456"
+      agency_name: agency
+      agency_domain_name: OTC002
+      delegated_project: eu-de_delegated
+    region_name: eu-de
+    interface: internal
+    verify: false
+  otc-sdk-agency:
+    auth:
+      auth_url: https://iam.eu-nl.otc.t-systems.com/v3
+      token: clouds-token
+      target_agency_name: sdk-agency
+      target_domain_id: OTC003
+      target_project_name: eu-nl_delegated
+    endpoint_type: admin
+  otc-regions:
+    auth:
+      auth_url: https://iam.eu-nl.otc.t-systems.com/v3
+      user_id: user-id
+      credential_process: print-credentials
+    regions:
+      - eu-nl
+`
+	secureYaml := `
+clouds:
+  otc-full:
+    auth:
+      password: secure-password
+      ak: secure-ak
+      sk: secure-sk
+      security_token: secure-security-token
+`
+	th.AssertNoErr(t, ioutil.WriteFile(fileName, []byte(cloudsYaml), 0600))
+	defer func() { _ = os.Remove(fileName) }()
+	th.AssertNoErr(t, ioutil.WriteFile(secureFileName, []byte(secureYaml), 0600))
+	defer func() { _ = os.Remove(secureFileName) }()
+
+	cases := []struct {
+		name     string
+		config   Config
+		expected map[string]string
+		insecure bool
+	}{
+		{
+			// secure.yaml values are used only for the settings missing in clouds.yaml
+			name:   "clouds.yaml and secure.yaml",
+			config: Config{Cloud: "otc-full"},
+			expected: map[string]string{
+				"IdentityEndpoint": "https://iam.eu-de.otc.t-systems.com/v3",
+				"Username":         "user",
+				"Password":         "secure-password",
+				"TenantName":       "eu-de_project",
+				"DomainName":       "OTC001",
+				"AccessKey":        "clouds-ak",
+				"SecretKey":        "secure-sk",
+				"SecurityToken":    "secure-security-token",
+				"Passcode":         "123456",
+				"AgencyName":       "agency",
+				"AgencyDomainName": "OTC002",
+				"DelegatedProject": "eu-de_delegated",
+				"Region":           "eu-de",
+				"EndpointType":     "internal",
+			},
+			insecure: true,
+		},
+		{
+			name: "provider arguments",
+			config: Config{
+				Cloud:            "otc-full",
+				IdentityEndpoint: "https://iam.eu-nl.otc.t-systems.com/v3",
+				Password:         "hcl-password",
+				AccessKey:        "hcl-ak",
+				SecretKey:        "hcl-sk",
+				SecurityToken:    "hcl-security-token",
+				AgencyName:       "hcl-agency",
+				Region:           "eu-nl",
+				EndpointType:     "public",
+			},
+			expected: map[string]string{
+				"IdentityEndpoint": "https://iam.eu-nl.otc.t-systems.com/v3",
+				"Username":         "user",
+				"Password":         "hcl-password",
+				"AccessKey":        "hcl-ak",
+				"SecretKey":        "hcl-sk",
+				"SecurityToken":    "hcl-security-token",
+				"AgencyName":       "hcl-agency",
+				"AgencyDomainName": "OTC002",
+				"Region":           "eu-nl",
+				"EndpointType":     "public",
+			},
+			insecure: true,
+		},
+		{
+			name:   "SDK agency settings",
+			config: Config{Cloud: "otc-sdk-agency"},
+			expected: map[string]string{
+				"Token":            "clouds-token",
+				"AgencyName":       "sdk-agency",
+				"AgencyDomainName": "OTC003",
+				"DelegatedProject": "eu-nl_delegated",
+				"Region":           "eu-nl",
+				"EndpointType":     "admin",
+			},
+		},
+		{
+			name:   "region list",
+			config: Config{Cloud: "otc-regions", Username: "user"},
+			expected: map[string]string{
+				"UserID":            "user-id",
+				"Username":          "",
+				"CredentialProcess": "print-credentials",
+				"Region":            "eu-nl",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := tc.config
+			config.environment = openstack.NewEnv(osPrefix, false)
+			th.AssertNoErr(t, config.Load())
+			for field, expected := range tc.expected {
+				actual := reflect.ValueOf(config).FieldByName(field).String()
+				if actual != expected {
+					t.Errorf("Field %s: expected %s, got %s", field, expected, actual)
+				}
+			}
+			th.AssertEquals(t, tc.insecure, config.Insecure)
+		})
+	}
+}
+
+func TestQuotaTrackerReserve(t *testing.T) {
+	loads := 0
+	load := func(region string) (map[string]Quota, error) {
+		loads++
+		return map[string]Quota{
+			"instances": {Used: 8, Limit: 10},
+			"cores":     {Used: 4, Limit: -1},
+		}, nil
+	}
+
+	tracker := newQuotaTracker()
+	th.AssertNoErr(t, tracker.reserve("project", "eu-de", load, map[string]int{"instances": 1, "cores": 100}))
+	th.AssertNoErr(t, tracker.reserve("project", "eu-de", load, map[string]int{"instances": 1, "volumes": 5}))
+	th.AssertEquals(t, 1, loads)
+
+	err := tracker.reserve("project", "eu-de", load, map[string]int{"instances": 1})
+	if err == nil {
+		t.Fatal("quota exceeded error expected")
+	}
+	th.AssertEquals(t,
+		"quota exceeded in region eu-de:\n  instances: 1 required, 0 left (limit 10, used 8, planned 2)",
+		err.Error(),
+	)
+
+	// failed reservation is not counted
+	th.AssertEquals(t, 2, tracker.reserved["project/eu-de"]["instances"])
+
+	// quotas are tracked per project and region
+	th.AssertNoErr(t, tracker.reserve("project", "eu-nl", load, map[string]int{"instances": 2}))
+	th.AssertEquals(t, 2, loads)
+
+	failingLoad := func(region string) (map[string]Quota, error) {
+		return nil, fmt.Errorf("forbidden")
+	}
+	th.AssertNoErr(t, tracker.reserve("other", "eu-de", failingLoad, map[string]int{"instances": 100}))
+}
+
+func TestCachedLookup(t *testing.T) {
+	config := &Config{TenantName: "project", lookups: newLookupCache()}
+
+	calls := 0
+	lookup := func() (interface{}, error) {
+		calls++
+		if calls == 1 {
+			return nil, fmt.Errorf("temporary error")
+		}
+		return []string{"s2.large.2"}, nil
+	}
+
+	_, err := config.CachedLookup("flavors", lookup)
+	if err == nil {
+		t.Fatal("lookup error expected")
+	}
+
+	for i := 0; i < 2; i++ {
+		value, err := config.CachedLookup("flavors", lookup)
+		th.AssertNoErr(t, err)
+		th.AssertDeepEquals(t, []string{"s2.large.2"}, value)
+	}
+	th.AssertEquals(t, 2, calls)
+}
+
+func TestCachedLookupExpiration(t *testing.T) {
+	defer func(ttl time.Duration) { lookupTTL = ttl }(lookupTTL)
+	lookupTTL = 50 * time.Millisecond
+
+	config := &Config{TenantName: "project", lookups: newLookupCache()}
+	calls := 0
+	lookup := func() (interface{}, error) {
+		calls++
+		return calls, nil
+	}
+
+	value, err := config.CachedLookup("types", lookup)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, value)
+	value, _ = config.CachedLookup("types", lookup)
+	th.AssertEquals(t, 1, value)
+
+	time.Sleep(2 * lookupTTL)
+	value, _ = config.CachedLookup("types", lookup)
+	th.AssertEquals(t, 2, value)
+}
+
+func TestCachedLookupConcurrent(t *testing.T) {
+	config := &Config{TenantName: "project", lookups: newLookupCache()}
+
+	var calls int32
+	release := make(chan struct{})
+	lookup := func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "value", nil
+	}
+
+	const callers = 20
+	wg := sync.WaitGroup{}
+	wg.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer wg.Done()
+			value, err := config.CachedLookup("types", lookup)
+			th.AssertNoErr(t, err)
+			th.AssertEquals(t, "value", value)
+		}()
+	}
+	// let all callers reach the cache before the lookup finishes
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	th.AssertEquals(t, int32(1), atomic.LoadInt32(&calls))
+}
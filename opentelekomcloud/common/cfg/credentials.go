@@ -0,0 +1,135 @@
+package cfg
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	awsCredentials "github.com/aws/aws-sdk-go/aws/credentials"
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/identity/v3/credentials"
+)
+
+// refreshBeforeExpiry is how long before the expiration temporary credentials are refreshed
+var refreshBeforeExpiry = 5 * time.Minute
+
+// credentialsSource issues new temporary credentials
+type credentialsSource func() (*credentials.TemporaryCredential, error)
+
+// temporaryCredentials caches temporary AK/SK issued by the source and refreshes them
+// when they are requested shortly before the expiration
+type temporaryCredentials struct {
+	mut       sync.Mutex
+	source    credentialsSource
+	cached    *credentials.TemporaryCredential
+	expiresAt time.Time
+}
+
+func newTemporaryCredentials(source credentialsSource) *temporaryCredentials {
+	return &temporaryCredentials{source: source}
+}
+
+// staticCredentials returns credentials source always returning the same not expiring credentials
+func staticCredentials(accessKey, secretKey, securityToken string) credentialsSource {
+	return func() (*credentials.TemporaryCredential, error) {
+		return &credentials.TemporaryCredential{
+			AccessKey:     accessKey,
+			SecretKey:     secretKey,
+			SecurityToken: securityToken,
+		}, nil
+	}
+}
+
+// Get returns cached credentials, refreshing them if they expire soon
+func (t *temporaryCredentials) Get() (*credentials.TemporaryCredential, error) {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	if t.cached != nil && !t.expiring() {
+		return t.cached, nil
+	}
+	if err := t.refresh(); err != nil {
+		return nil, err
+	}
+	return t.cached, nil
+}
+
+// refresh issues new credentials, it should be called under the lock
+func (t *temporaryCredentials) refresh() error {
+	credential, err := t.source()
+	if err != nil {
+		return err
+	}
+	var expiresAt time.Time
+	if credential.ExpiresAt != "" {
+		expiresAt, err = time.Parse(time.RFC3339Nano, credential.ExpiresAt)
+		if err != nil {
+			return fmt.Errorf("error parsing credentials expiration time: %s", err)
+		}
+	}
+	t.cached = credential
+	t.expiresAt = expiresAt
+	return nil
+}
+
+// IsExpiring checks if cached credentials are missing or expire soon
+func (t *temporaryCredentials) IsExpiring() bool {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	return t.cached == nil || t.expiring()
+}
+
+func (t *temporaryCredentials) expiring() bool {
+	return !t.expiresAt.IsZero() && time.Until(t.expiresAt) < refreshBeforeExpiry
+}
+
+// signingTransport re-signs AK/SK signed requests with the current temporary credentials
+// adding the security token, so the refreshed credentials are used by existing clients
+type signingTransport struct {
+	rt          http.RoundTripper
+	credentials *temporaryCredentials
+}
+
+func (t *signingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if !strings.HasPrefix(request.Header.Get("Authorization"), golangsdk.SignAlgorithmHMACSHA256) {
+		return t.rt.RoundTrip(request)
+	}
+	credential, err := t.credentials.Get()
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing temporary credentials: %s", err)
+	}
+
+	signed := request.Clone(request.Context())
+	if credential.SecurityToken != "" {
+		signed.Header.Set("X-Security-Token", credential.SecurityToken)
+	}
+	golangsdk.ReSign(signed, golangsdk.SignOptions{
+		AccessKey: credential.AccessKey,
+		SecretKey: credential.SecretKey,
+	})
+	return t.rt.RoundTrip(signed)
+}
+
+// awsCredentialsProvider provides temporary credentials to the AWS SDK used by S3 resources
+type awsCredentialsProvider struct {
+	credentials *temporaryCredentials
+}
+
+func (p *awsCredentialsProvider) Retrieve() (awsCredentials.Value, error) {
+	credential, err := p.credentials.Get()
+	if err != nil {
+		return awsCredentials.Value{}, err
+	}
+	return awsCredentials.Value{
+		AccessKeyID:     credential.AccessKey,
+		SecretAccessKey: credential.SecretKey,
+		SessionToken:    credential.SecurityToken,
+		ProviderName:    "OpenTelekomCloudTemporaryCredentials",
+	}, nil
+}
+
+func (p *awsCredentialsProvider) IsExpired() bool {
+	return p.credentials.IsExpiring()
+}
@@ -0,0 +1,59 @@
+package cfg
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+)
+
+// EndpointServices lists services which endpoints can be overridden with `endpoints`
+var EndpointServices = []string{
+	"antiddos", "as", "cbr", "cce", "ces", "css", "csbs", "cts", "dcs", "dds", "deh", "dms", "dns", "ecs",
+	"elb", "evs", "iam", "ims", "kms", "lts", "mrs", "nat", "obs", "rds", "rts", "sdrs", "sfs", "sfs_turbo",
+	"smn", "swr", "vbs", "vpc", "waf",
+}
+
+// overrideEndpoint replaces scheme and host of the service client endpoint with the one set in `endpoints`.
+// Path of the overriding URL is used as a prefix for the catalog endpoint path.
+func (c *Config) overrideEndpoint(service string, client *golangsdk.ServiceClient) (*golangsdk.ServiceClient, error) {
+	override := c.Endpoints[service]
+	if override == "" {
+		return client, nil
+	}
+	endpoint, err := replaceEndpointBase(client.Endpoint, override)
+	if err != nil {
+		return nil, fmt.Errorf("error overriding %s endpoint: %s", service, err)
+	}
+	if client.ResourceBase != "" {
+		resourceBase, err := replaceEndpointBase(client.ResourceBase, override)
+		if err != nil {
+			return nil, fmt.Errorf("error overriding %s endpoint: %s", service, err)
+		}
+		client.ResourceBase = resourceBase
+	}
+	client.Endpoint = endpoint
+	return client, nil
+}
+
+func replaceEndpointBase(endpoint, base string) (string, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	if baseURL.Scheme == "" || baseURL.Host == "" {
+		return "", fmt.Errorf("endpoint %q should be an absolute URL", base)
+	}
+	endpointURL.Scheme = baseURL.Scheme
+	endpointURL.Host = baseURL.Host
+	endpointURL.Path = strings.TrimSuffix(baseURL.Path, "/") + endpointURL.Path
+	if endpointURL.Path == "" {
+		endpointURL.Path = "/"
+	}
+	return endpointURL.String(), nil
+}
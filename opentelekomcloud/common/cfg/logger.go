@@ -8,8 +8,10 @@ import (
 	"io/ioutil"
 	"log"
 	"math"
+	"math/rand"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -24,6 +26,39 @@ type RoundTripper struct {
 	Rt         http.RoundTripper
 	OsDebug    bool
 	MaxRetries int
+	// Cassette records or replays HTTP interactions, see CassetteFromEnv
+	Cassette *Cassette
+	// SensitiveKeys are the keys of body fields masked in addition to DefaultSensitiveKeys
+	SensitiveKeys []string
+
+	tracer *tracer
+}
+
+// retryableStatusCodes are the response codes which signal throttling or temporary unavailability
+// of the API gateway. Only 429 and 503 guarantee that the request was not processed by the backend.
+var retryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+func isRetryableStatus(code int) bool {
+	for _, c := range retryableStatusCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// isIdempotent checks if request with given method can be safely repeated
+func isIdempotent(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
 }
 
 func retryTimeout(count int) time.Duration {
@@ -35,8 +70,93 @@ func retryTimeout(count int) time.Duration {
 	return timeout
 }
 
+// jitteredTimeout returns random timeout between half and full retryTimeout,
+// so parallel requests failed at the same moment won't be retried at the same moment
+func jitteredTimeout(count int) time.Duration {
+	timeout := retryTimeout(count)
+	half := int64(timeout / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+// retryAfter parses `Retry-After` header value, which can be either
+// number of seconds or HTTP date. Returns 0 if header is missing or invalid.
+func retryAfter(response *http.Response) time.Duration {
+	value := response.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	var timeout time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		timeout = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		timeout = time.Until(date)
+	}
+	if timeout < 0 {
+		return 0
+	}
+	if timeout > maxTimeout {
+		timeout = maxTimeout
+	}
+	return timeout
+}
+
+// rewindBody resets request body to the beginning, so the request can be sent again.
+// Returns false if the body can't be rewound.
+func rewindBody(request *http.Request) (bool, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return true, nil
+	}
+	if request.GetBody == nil {
+		return false, nil
+	}
+	body, err := request.GetBody()
+	if err != nil {
+		return false, err
+	}
+	request.Body = body
+	return true, nil
+}
+
+// canReplay checks if the request can be sent once more after receiving retryable response.
+// The body has to be either missing or buffered, so it can be rewound. Requests with non-idempotent
+// methods are replayed only if the response guarantees that the request was not processed,
+// gateway errors can be returned after e.g. the resource is already created.
+func canReplay(request *http.Request, statusCode int) bool {
+	noBody := request.Body == nil || request.Body == http.NoBody
+	if !noBody && request.GetBody == nil {
+		return false
+	}
+	if isIdempotent(request.Method) {
+		return true
+	}
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+}
+
+// drainBody reads the rest of the response body and closes it, so the connection can be reused
+func drainBody(response *http.Response) {
+	if response == nil || response.Body == nil {
+		return
+	}
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+	_ = response.Body.Close()
+}
+
 // RoundTrip performs a round-trip HTTP request and logs relevant information about it.
 func (lrt *RoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	var callID string
+	if lrt.tracer != nil || lrt.OsDebug {
+		callID = newCallID()
+	}
+	start := time.Now()
+	response, retries, err := lrt.roundTrip(request, callID)
+	if lrt.tracer != nil {
+		lrt.tracer.trace(callID, request, response, retries, time.Since(start), err)
+	}
+	return response, err
+}
+
+// roundTrip sends the request retrying it if needed, returns the response and the number of retries made
+func (lrt *RoundTripper) roundTrip(request *http.Request, callID string) (*http.Response, int, error) {
 	defer func() {
 		if request.Body != nil {
 			request.Body.Close()
@@ -49,68 +169,106 @@ func (lrt *RoundTripper) RoundTrip(request *http.Request) (*http.Response, error
 	var err error
 
 	if lrt.OsDebug {
-		log.Printf("[DEBUG] OpenTelekomCloud Request URL: %s %s", request.Method, request.URL)
+		log.Printf("[DEBUG] OpenTelekomCloud Request URL: %s %s (call %s)", request.Method, request.URL, callID)
 		log.Printf("[DEBUG] OpenTelekomCloud Request Headers:\n%s", formatHeaders(request.Header, "\n"))
 
-		if request.Body != nil {
-			request.Body, err = lrt.logRequest(request.Body, request.Header.Get("Content-Type"))
-			if err != nil {
-				return nil, err
+		if request.Body != nil && request.Body != http.NoBody {
+			if err := lrt.logRequest(request); err != nil {
+				return nil, 0, err
 			}
 		}
 	}
 
-	response, err := lrt.Rt.RoundTrip(request)
-	// Retrying connection
-	retry := 1
-	for response == nil {
+	response, err := lrt.send(request)
 
-		if retry > lrt.MaxRetries {
+	for retry := 1; ; retry++ {
+		var timeout time.Duration
+		switch {
+		case response == nil:
+			// Retrying connection
+			if retry > lrt.MaxRetries {
+				if lrt.OsDebug {
+					log.Printf("[DEBUG] OpenTelecomCloud connection error, retries exhausted. Aborting")
+				}
+				err = fmt.Errorf("OpenTelecomCloud connection error, retries exhausted. Aborting. Last error was: %s", err)
+				return nil, retry - 1, err
+			}
 			if lrt.OsDebug {
-				log.Printf("[DEBUG] OpenTelecomCloud connection error, retries exhausted. Aborting")
+				log.Printf("[DEBUG] OpenTelecomCloud connection error, retry number %d: %s", retry, err)
 			}
-			err = fmt.Errorf("OpenTelecomCloud connection error, retries exhausted. Aborting. Last error was: %s", err)
-			return nil, err
-		}
+			timeout = jitteredTimeout(retry)
+		case isRetryableStatus(response.StatusCode) && retry <= lrt.MaxRetries && canReplay(request, response.StatusCode):
+			if lrt.OsDebug {
+				log.Printf("[DEBUG] OpenTelecomCloud responded with %d, retry number %d", response.StatusCode, retry)
+			}
+			timeout = retryAfter(response)
+			if timeout == 0 {
+				timeout = jitteredTimeout(retry)
+			}
+			drainBody(response)
+		default:
+			if lrt.OsDebug {
+				log.Printf("[DEBUG] OpenTelekomCloud Response Code: %d", response.StatusCode)
+				log.Printf("[DEBUG] OpenTelekomCloud Response Headers:\n%s", formatHeaders(response.Header, "\n"))
 
-		if lrt.OsDebug {
-			log.Printf("[DEBUG] OpenTelecomCloud connection error, retry number %d: %s", retry, err)
+				response.Body, err = lrt.logResponse(response.Body, response.Header.Get("Content-Type"))
+			}
+			return response, retry - 1, err
 		}
-		time.Sleep(retryTimeout(retry))
-		response, err = lrt.Rt.RoundTrip(request)
-		retry += 1
-	}
 
-	if lrt.OsDebug {
-		log.Printf("[DEBUG] OpenTelekomCloud Response Code: %d", response.StatusCode)
-		log.Printf("[DEBUG] OpenTelekomCloud Response Headers:\n%s", formatHeaders(response.Header, "\n"))
+		rewound, rewindErr := rewindBody(request)
+		if rewindErr != nil {
+			return nil, retry - 1, fmt.Errorf("error rewinding request body for retry: %s", rewindErr)
+		}
+		if !rewound {
+			return nil, retry - 1, fmt.Errorf("OpenTelecomCloud connection error, request body can't be sent again: %s", err)
+		}
 
-		response.Body, err = lrt.logResponse(response.Body, response.Header.Get("Content-Type"))
+		select {
+		case <-request.Context().Done():
+			return nil, retry - 1, request.Context().Err()
+		case <-time.After(timeout):
+		}
+		response, err = lrt.send(request)
 	}
+}
 
-	return response, err
+// send sends the request using underlying transport, recording or replaying it when cassette is set
+func (lrt *RoundTripper) send(request *http.Request) (*http.Response, error) {
+	if lrt.Cassette == nil {
+		return lrt.Rt.RoundTrip(request)
+	}
+	return lrt.Cassette.roundTrip(lrt.Rt, request, lrt.sensitiveKeys())
 }
 
 // logRequest will log the HTTP Request details.
 // If the body is JSON, it will attempt to be pretty-formatted.
-func (lrt *RoundTripper) logRequest(original io.ReadCloser, contentType string) (io.ReadCloser, error) {
+// The body is buffered, so the request can be replayed on retry.
+func (lrt *RoundTripper) logRequest(request *http.Request) error {
+	original := request.Body
 	defer original.Close()
 
 	var bs bytes.Buffer
 	_, err := io.Copy(&bs, original)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Handle request contentType
-	if strings.HasPrefix(contentType, "application/json") {
+	if strings.HasPrefix(request.Header.Get("Content-Type"), "application/json") {
 		debugInfo := lrt.formatJSON(bs.Bytes())
 		log.Printf("[DEBUG] OpenTelekomCloud Request Body: %s", debugInfo)
 	} else {
-		log.Printf("[DEBUG] OpenTelekomCloud Request Body: %s", bs.String())
+		debugInfo := maskBody(bs.Bytes(), request.Header.Get("Content-Type"), lrt.sensitiveKeys())
+		log.Printf("[DEBUG] OpenTelekomCloud Request Body: %s", debugInfo)
 	}
 
-	return ioutil.NopCloser(strings.NewReader(bs.String())), nil
+	buffered := bs.Bytes()
+	request.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(buffered)), nil
+	}
+	request.Body, _ = request.GetBody()
+	return nil
 }
 
 // logResponse will log the HTTP Response details.
@@ -135,7 +293,7 @@ func (lrt *RoundTripper) logResponse(original io.ReadCloser, contentType string)
 	if _, err := io.Copy(&buf, original); err != nil {
 		return nil, err
 	}
-	log.Printf("[DEBUG] the response is: %s", buf.String())
+	log.Printf("[DEBUG] the response is: %s", maskBody(buf.Bytes(), contentType, lrt.sensitiveKeys()))
 	log.Printf("[DEBUG] Not logging because OpenTelekomCloud response body isn't JSON")
 	return ioutil.NopCloser(strings.NewReader(buf.String())), nil
 
@@ -144,36 +302,29 @@ func (lrt *RoundTripper) logResponse(original io.ReadCloser, contentType string)
 // formatJSON will try to pretty-format a JSON body.
 // It will also mask known fields which contain sensitive information.
 func (lrt *RoundTripper) formatJSON(raw []byte) string {
-	var data map[string]interface{}
+	var data interface{}
 
 	err := json.Unmarshal(raw, &data)
 	if err != nil {
 		log.Printf("[DEBUG] Unable to parse OpenTelekomCloud JSON: %s", err)
-		return string(raw)
+		return maskText(string(raw), lrt.sensitiveKeys())
 	}
 
-	// Mask known password fields
-	if v, ok := data["auth"].(map[string]interface{}); ok {
-		if v, ok := v["identity"].(map[string]interface{}); ok {
-			if v, ok := v["password"].(map[string]interface{}); ok {
-				if v, ok := v["user"].(map[string]interface{}); ok {
-					v["password"] = "***"
-				}
-			}
-		}
-	}
+	maskJSON(data, nil, lrt.sensitiveKeys())
 
 	// Ignore the catalog
-	if v, ok := data["token"].(map[string]interface{}); ok {
-		if _, ok := v["catalog"]; ok {
-			return ""
+	if v, ok := data.(map[string]interface{}); ok {
+		if v, ok := v["token"].(map[string]interface{}); ok {
+			if _, ok := v["catalog"]; ok {
+				return ""
+			}
 		}
 	}
 
 	pretty, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
 		log.Printf("[DEBUG] Unable to re-marshal OpenTelekomCloud JSON: %s", err)
-		return string(raw)
+		return maskText(string(raw), lrt.sensitiveKeys())
 	}
 
 	return string(pretty)
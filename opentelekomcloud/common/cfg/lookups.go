@@ -0,0 +1,79 @@
+package cfg
+
+import (
+	"sync"
+	"time"
+)
+
+// lookupTTL is the time the lookup results are cached for
+var lookupTTL = 10 * time.Minute
+
+// LookupFunc loads the value of the cached lookup
+type LookupFunc func() (interface{}, error)
+
+type lookupEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// lookupCall is the lookup in progress, concurrent callers wait for it instead of doing the same lookup
+type lookupCall struct {
+	wg    sync.WaitGroup
+	value interface{}
+	err   error
+}
+
+// lookupCache memoises results of the lookups done during the terraform run, e.g. volume types or flavor lists
+// used by plan-time validation, so they are loaded once and not for every resource.
+// It is safe for concurrent use by the resources processed in parallel.
+type lookupCache struct {
+	mut      sync.Mutex
+	entries  map[string]lookupEntry
+	inFlight map[string]*lookupCall
+}
+
+func newLookupCache() *lookupCache {
+	return &lookupCache{
+		entries:  make(map[string]lookupEntry),
+		inFlight: make(map[string]*lookupCall),
+	}
+}
+
+// CachedLookup returns the cached value of the lookup with the given key, loading it on the first call
+// or after the cached value expires. Concurrent calls with the same key share a single lookup.
+// Errors are not cached. Key is scoped to the project, the cache is shared with the project configs.
+func (c *Config) CachedLookup(key string, lookup LookupFunc) (interface{}, error) {
+	if c.lookups == nil {
+		return lookup()
+	}
+	return c.lookups.get(string(c.GetProjectName(nil))+"/"+key, lookup)
+}
+
+func (l *lookupCache) get(key string, lookup LookupFunc) (interface{}, error) {
+	l.mut.Lock()
+	if entry, ok := l.entries[key]; ok && time.Now().Before(entry.expiresAt) {
+		l.mut.Unlock()
+		return entry.value, nil
+	}
+	if call, ok := l.inFlight[key]; ok {
+		l.mut.Unlock()
+		call.wg.Wait()
+		return call.value, call.err
+	}
+	call := new(lookupCall)
+	call.wg.Add(1)
+	l.inFlight[key] = call
+	l.mut.Unlock()
+
+	call.value, call.err = lookup()
+
+	l.mut.Lock()
+	delete(l.inFlight, key)
+	if call.err == nil {
+		l.entries[key] = lookupEntry{value: call.value, expiresAt: time.Now().Add(lookupTTL)}
+	}
+	l.mut.Unlock()
+	call.wg.Done()
+
+	return call.value, call.err
+}
@@ -0,0 +1,131 @@
+package cfg
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const maskedValue = "***"
+
+// DefaultSensitiveKeys are the keys of request and response body fields which are masked
+// in debug logs and HTTP cassettes. Keys are case-insensitive. Key containing dots matches
+// the end of the field path, e.g. `credential.secret` matches only `secret` field of `credential` object.
+var DefaultSensitiveKeys = []string{
+	"password",
+	"passcode",
+	"identity.token.id",
+	"db.password",
+	"user_passwd",
+	"admin_pass",
+	"adminPass",
+	"psk",
+	"access_key",
+	"secret_key",
+	"security_token",
+	"credential.access",
+	"credential.secret",
+	"credential.securitytoken",
+}
+
+// sensitiveKeys returns default sensitive keys extended with the configured ones
+func (lrt *RoundTripper) sensitiveKeys() []string {
+	return append(append([]string{}, DefaultSensitiveKeys...), lrt.SensitiveKeys...)
+}
+
+// keyMatches checks if the field with given path matches the sensitive key
+func keyMatches(path []string, key string) bool {
+	parts := strings.Split(key, ".")
+	if len(parts) > len(path) {
+		return false
+	}
+	offset := len(path) - len(parts)
+	for i, part := range parts {
+		if !strings.EqualFold(part, path[offset+i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isSensitive(path []string, keys []string) bool {
+	for _, key := range keys {
+		if keyMatches(path, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// maskJSON recursively masks values of the sensitive fields in unmarshalled JSON.
+// Array items have the same path as the array itself.
+func maskJSON(data interface{}, path []string, keys []string) {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		for k, v := range value {
+			fieldPath := append(path[:len(path):len(path)], k)
+			if isSensitive(fieldPath, keys) {
+				if _, isObject := v.(map[string]interface{}); !isObject {
+					value[k] = maskedValue
+					continue
+				}
+			}
+			maskJSON(v, fieldPath, keys)
+		}
+	case []interface{}:
+		for _, v := range value {
+			maskJSON(v, path, keys)
+		}
+	}
+}
+
+// maskForm masks values of the sensitive fields in URL-encoded form body
+func maskForm(raw string, keys []string) (string, error) {
+	values, err := url.ParseQuery(raw)
+	if err != nil {
+		return "", err
+	}
+	for k := range values {
+		if isSensitive([]string{k}, keys) {
+			for i := range values[k] {
+				values[k][i] = maskedValue
+			}
+		}
+	}
+	return values.Encode(), nil
+}
+
+// maskText masks `key=value`, `key: value` pairs and `<key>value</key>` elements in arbitrary text body
+func maskText(raw string, keys []string) string {
+	for _, key := range keys {
+		parts := strings.Split(key, ".")
+		name := regexp.QuoteMeta(parts[len(parts)-1])
+		pair := regexp.MustCompile(fmt.Sprintf(`(?i)(\b%s"?\s*[=:]\s*"?)[^"&,\s<]*`, name))
+		raw = pair.ReplaceAllString(raw, "${1}"+maskedValue)
+		element := regexp.MustCompile(fmt.Sprintf(`(?is)(<%[1]s>).*?(</%[1]s>)`, name))
+		raw = element.ReplaceAllString(raw, "${1}"+maskedValue+"${2}")
+	}
+	return raw
+}
+
+// maskBody masks sensitive fields in the body of any content type
+func maskBody(raw []byte, contentType string, keys []string) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var data interface{}
+	if err := json.Unmarshal(raw, &data); err == nil {
+		maskJSON(data, nil, keys)
+		if masked, err := json.Marshal(data); err == nil {
+			return string(masked)
+		}
+	}
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if masked, err := maskForm(string(raw), keys); err == nil {
+			return masked
+		}
+	}
+	return maskText(string(raw), keys)
+}
@@ -0,0 +1,48 @@
+package cfg
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/identity/v3/credentials"
+)
+
+const (
+	// defaultMetadataURL is the address of the ECS instance metadata service
+	defaultMetadataURL = "http://169.254.169.254"
+	// metadataTimeout is kept low as we don't want to wait outside of ECS instance
+	metadataTimeout = 2 * time.Second
+)
+
+// metadataCredentials returns credentials source using temporary AK/SK of the agency
+// attached to the ECS instance, which are provided by the instance metadata service
+func metadataCredentials(metadataURL string) credentialsSource {
+	client := cleanhttp.DefaultClient()
+	client.Timeout = metadataTimeout
+	url := strings.TrimSuffix(metadataURL, "/") + "/openstack/latest/securitykey"
+
+	return func() (*credentials.TemporaryCredential, error) {
+		resp, err := client.Get(url)
+		if err != nil {
+			return nil, fmt.Errorf("error requesting instance metadata: %s", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 200 {
+			return nil, fmt.Errorf("instance metadata service responded with %d", resp.StatusCode)
+		}
+
+		var body struct {
+			Credential *credentials.TemporaryCredential `json:"credential"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			return nil, fmt.Errorf("error parsing instance metadata: %s", err)
+		}
+		if body.Credential == nil || body.Credential.AccessKey == "" || body.Credential.SecretKey == "" {
+			return nil, fmt.Errorf("no temporary credentials in instance metadata, is agency attached to the instance?")
+		}
+		return body.Credential, nil
+	}
+}
@@ -0,0 +1,50 @@
+package cfg
+
+import (
+	"sync"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/identity/v3/credentials"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/obs"
+)
+
+// obsClients shares OBS clients between all OBS resources, one client per endpoint.
+// Clients use shared temporary credentials: once the credentials are renewed,
+// all the existing clients are refreshed with them under the lock.
+type obsClients struct {
+	credentials *temporaryCredentials
+
+	mut     sync.Mutex
+	current *credentials.TemporaryCredential
+	clients map[string]*obs.ObsClient
+}
+
+func newObsClients(credentials *temporaryCredentials) *obsClients {
+	return &obsClients{credentials: credentials, clients: make(map[string]*obs.ObsClient)}
+}
+
+// get returns the shared OBS client for the endpoint, refreshing the clients if the credentials are renewed
+func (o *obsClients) get(endpoint string) (*obs.ObsClient, error) {
+	credential, err := o.credentials.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	o.mut.Lock()
+	defer o.mut.Unlock()
+
+	if credential != o.current {
+		for _, client := range o.clients {
+			client.Refresh(credential.AccessKey, credential.SecretKey, credential.SecurityToken)
+		}
+		o.current = credential
+	}
+	if client, ok := o.clients[endpoint]; ok {
+		return client, nil
+	}
+	client, err := obs.New(credential.AccessKey, credential.SecretKey, endpoint, obs.WithSecurityToken(credential.SecurityToken))
+	if err != nil {
+		return nil, err
+	}
+	o.clients[endpoint] = client
+	return client, nil
+}
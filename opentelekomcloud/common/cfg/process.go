@@ -0,0 +1,176 @@
+package cfg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/identity/v3/credentials"
+)
+
+// processTimeout limits the run time of the credential process
+var processTimeout = time.Minute
+
+// processOutput is the JSON printed by the credential process
+type processOutput struct {
+	AccessKey     string `json:"access_key"`
+	SecretKey     string `json:"secret_key"`
+	SecurityToken string `json:"security_token"`
+	Token         string `json:"token"`
+	// ExpiresAt is RFC3339 time of the expiration, credentials without it never expire
+	ExpiresAt string `json:"expires_at"`
+}
+
+// credentialProcess runs the external command printing credentials and caches its output
+// until the credentials expire
+type credentialProcess struct {
+	command string
+
+	mut       sync.Mutex
+	cached    *processOutput
+	expiresAt time.Time
+}
+
+func newCredentialProcess(command string) *credentialProcess {
+	return &credentialProcess{command: command}
+}
+
+// Get returns cached output of the process, re-running the process if the credentials expire soon
+func (p *credentialProcess) Get() (*processOutput, error) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	if p.cached != nil && (p.expiresAt.IsZero() || time.Until(p.expiresAt) >= refreshBeforeExpiry) {
+		return p.cached, nil
+	}
+	output, err := p.run()
+	if err != nil {
+		return nil, fmt.Errorf("error running credential process: %s", err)
+	}
+	var expiresAt time.Time
+	if output.ExpiresAt != "" {
+		expiresAt, err = time.Parse(time.RFC3339Nano, output.ExpiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing credential process expiration time: %s", err)
+		}
+	}
+	p.cached = output
+	p.expiresAt = expiresAt
+	return output, nil
+}
+
+// expireToken drops cached output if it contains the expired token, so the process is re-run on the next Get
+func (p *credentialProcess) expireToken(token string) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	if p.cached != nil && p.cached.Token == token {
+		p.cached = nil
+	}
+}
+
+func (p *credentialProcess) run() (*processOutput, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), processTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", p.command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", p.command)
+	}
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	output := new(processOutput)
+	if err := json.Unmarshal(stdout.Bytes(), output); err != nil {
+		return nil, fmt.Errorf("error parsing output: %s", err)
+	}
+	if output.Token == "" && (output.AccessKey == "" || output.SecretKey == "") {
+		return nil, fmt.Errorf("output contains neither token nor AK/SK")
+	}
+	return output, nil
+}
+
+// credentials is the source of AK/SK printed by the process
+func (p *credentialProcess) credentials() (*credentials.TemporaryCredential, error) {
+	output, err := p.Get()
+	if err != nil {
+		return nil, err
+	}
+	if output.AccessKey == "" || output.SecretKey == "" {
+		return nil, fmt.Errorf("credential process output contains no AK/SK")
+	}
+	return &credentials.TemporaryCredential{
+		AccessKey:     output.AccessKey,
+		SecretKey:     output.SecretKey,
+		SecurityToken: output.SecurityToken,
+		ExpiresAt:     output.ExpiresAt,
+	}, nil
+}
+
+// token returns the token printed by the process
+func (p *credentialProcess) token() (string, error) {
+	output, err := p.Get()
+	if err != nil {
+		return "", err
+	}
+	if output.Token == "" {
+		return "", fmt.Errorf("credential process output contains no token")
+	}
+	return output.Token, nil
+}
+
+// authenticateByProcess authenticates with the token or AK/SK printed by the credential process
+func (c *Config) authenticateByProcess() error {
+	process := newCredentialProcess(c.CredentialProcess)
+	output, err := process.Get()
+	if err != nil {
+		return err
+	}
+	if output.Token != "" {
+		log.Printf("[INFO] Using token from the credential process")
+		c.tokenProcess = process
+		c.Token = output.Token
+		return buildClientByToken(c)
+	}
+
+	log.Printf("[INFO] Using AK/SK from the credential process")
+	c.credentials = newTemporaryCredentials(process.credentials)
+	c.AccessKey = output.AccessKey
+	c.SecretKey = output.SecretKey
+	c.SecurityToken = output.SecurityToken
+	return buildClientByAKSK(c)
+}
+
+// withProcessToken returns auth options using the current token printed by the credential process
+func (c *Config) withProcessToken(ao golangsdk.AuthOptionsProvider) (golangsdk.AuthOptionsProvider, error) {
+	switch opts := ao.(type) {
+	case golangsdk.AuthOptions:
+		token, err := c.tokenProcess.token()
+		if err != nil {
+			return nil, err
+		}
+		opts.TokenID = token
+		return opts, nil
+	case assumeRoleOptions:
+		base, err := c.withProcessToken(opts.base)
+		if err != nil {
+			return nil, err
+		}
+		opts.base = base
+		return opts, nil
+	}
+	return ao, nil
+}
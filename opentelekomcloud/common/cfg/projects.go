@@ -0,0 +1,99 @@
+package cfg
+
+import (
+	"strings"
+	"sync"
+)
+
+// projectConfigs is a concurrency-safe cache of configs scoped to the different projects of the same domain
+type projectConfigs struct {
+	mu      sync.Mutex
+	configs map[ProjectName]*projectConfig
+}
+
+type projectConfig struct {
+	mu     sync.Mutex
+	config *Config
+}
+
+func newProjectConfigs() *projectConfigs {
+	return &projectConfigs{configs: make(map[ProjectName]*projectConfig)}
+}
+
+func (p *projectConfigs) get(projectName ProjectName) *projectConfig {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry, ok := p.configs[projectName]
+	if !ok {
+		entry = &projectConfig{}
+		p.configs[projectName] = entry
+	}
+	return entry
+}
+
+// ForProject returns config with clients scoped to the given project in the same domain.
+// Any `*Client(region)` factory of the returned config targets the given project,
+// the region of the returned config is the region of the project.
+// Scoped configs are cached, so authentication is done only once per project.
+// The returned config is scoped to the same Terraform resource, see ForResource.
+func (c *Config) ForProject(projectName ProjectName) (*Config, error) {
+	if projectName == "" || projectName == c.GetProjectName(nil) {
+		return c, nil
+	}
+	config, err := c.forProject(projectName)
+	if err != nil {
+		return nil, err
+	}
+	return config.ForResource(c.resource.Type, c.resource.ID), nil
+}
+
+func (c *Config) forProject(projectName ProjectName) (*Config, error) {
+	if c.projects == nil {
+		return c.reconfigProjectName(projectName)
+	}
+
+	entry := c.projects.get(projectName)
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.config != nil {
+		return entry.config, nil
+	}
+	config, err := c.reconfigProjectName(projectName)
+	if err != nil {
+		return nil, err
+	}
+	entry.config = config
+	return config, nil
+}
+
+// reconfigProjectName creates copy of config authenticated in the given project.
+// S3 session, rate limiters and project cache are shared with the source config.
+func (c *Config) reconfigProjectName(projectName ProjectName) (*Config, error) {
+	config := *c
+	config.resource = tracedResource{}
+	// project can be in the other region of the same domain
+	if config.Region != "" {
+		config.Region = strings.Split(string(projectName), "_")[0]
+	}
+	switch {
+	case len(config.AssumeRoles) != 0:
+		// project token is scoped to the delegated project of the last assumed agency,
+		// roles are copied, so the source config chain is not modified
+		config.AssumeRoles = append([]AssumeRole{}, c.AssumeRoles...)
+		config.AssumeRoles[len(config.AssumeRoles)-1].DelegatedProject = string(projectName)
+		config.TenantName = ""
+		config.TenantID = ""
+		config.DelegatedProject = ""
+	case config.AgencyName != "" && config.AgencyDomainName != "":
+		config.DelegatedProject = string(projectName)
+	default:
+		config.TenantName = string(projectName)
+		config.TenantID = ""
+	}
+	if err := config.authenticate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
@@ -0,0 +1,86 @@
+package cfg
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Quota is the usage and the limit of the project quota, limit `-1` means no limit
+type Quota struct {
+	Used  int
+	Limit int
+}
+
+// QuotaLoader loads current quotas of the region
+type QuotaLoader func(region string) (map[string]Quota, error)
+
+// quotaTracker tracks quotas reserved by the planned resources during the terraform run.
+// Quotas are loaded once per region, so resources created during apply are not counted twice.
+type quotaTracker struct {
+	mut      sync.Mutex
+	quotas   map[string]map[string]Quota
+	reserved map[string]map[string]int
+}
+
+func newQuotaTracker() *quotaTracker {
+	return &quotaTracker{
+		quotas:   make(map[string]map[string]Quota),
+		reserved: make(map[string]map[string]int),
+	}
+}
+
+// ReserveQuota checks that the quotas left in the region are enough for the required amounts taking
+// into account the quotas reserved by other planned resources and reserves the required amounts.
+// Quotas are tracked only in the configured provider, the tracker is shared with the project configs.
+func (c *Config) ReserveQuota(region string, load QuotaLoader, required map[string]int) error {
+	if c.quotas == nil {
+		return nil
+	}
+	return c.quotas.reserve(string(c.GetProjectName(nil)), region, load, required)
+}
+
+func (t *quotaTracker) reserve(project, region string, load QuotaLoader, required map[string]int) error {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	key := project + "/" + region
+	quotas, ok := t.quotas[key]
+	if !ok {
+		loaded, err := load(region)
+		if err != nil {
+			// plan shouldn't fail because of e.g. missing permissions
+			log.Printf("[WARN] Error loading quotas, quotas are not validated: %s", err)
+			loaded = make(map[string]Quota)
+		}
+		quotas = loaded
+		t.quotas[key] = quotas
+		t.reserved[key] = make(map[string]int)
+	}
+	reserved := t.reserved[key]
+
+	var exceeded []string
+	for name, amount := range required {
+		quota, ok := quotas[name]
+		if !ok || quota.Limit < 0 || amount <= 0 {
+			continue
+		}
+		if left := quota.Limit - quota.Used - reserved[name]; amount > left {
+			exceeded = append(exceeded, fmt.Sprintf(
+				"%s: %d required, %d left (limit %d, used %d, planned %d)",
+				name, amount, left, quota.Limit, quota.Used, reserved[name],
+			))
+		}
+	}
+	if len(exceeded) != 0 {
+		sort.Strings(exceeded)
+		return fmt.Errorf("quota exceeded in region %s:\n  %s", region, strings.Join(exceeded, "\n  "))
+	}
+
+	for name, amount := range required {
+		reserved[name] += amount
+	}
+	return nil
+}
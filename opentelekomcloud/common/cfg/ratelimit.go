@@ -0,0 +1,121 @@
+package cfg
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit describes client-side limit of requests sent to the service endpoint
+type RateLimit struct {
+	// Service is either the first label of endpoint host (e.g. `vpc` for `vpc.eu-de.otc.t-systems.com`)
+	// or the full endpoint host
+	Service           string
+	RequestsPerSecond float64
+}
+
+func (l RateLimit) matches(host string) bool {
+	return host == l.Service || strings.HasPrefix(host, l.Service+".")
+}
+
+// tokenBucket is a simple token bucket limiter allowing `rate` requests per second
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64) *tokenBucket {
+	burst := math.Max(1, math.Ceil(rate))
+	return &tokenBucket{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token from the bucket and returns time to wait before the token can be used
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// Wait blocks until a request can be sent or the context is cancelled
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	delay := b.reserve()
+	if delay == 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// endpointLimiters holds token buckets per endpoint host
+type endpointLimiters struct {
+	limits []RateLimit
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+func newEndpointLimiters(limits []RateLimit) *endpointLimiters {
+	return &endpointLimiters{
+		limits:  limits,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// forHost returns the limiter for the given host, `nil` is returned for not limited endpoints
+func (e *endpointLimiters) forHost(host string) *tokenBucket {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if bucket, ok := e.buckets[host]; ok {
+		return bucket
+	}
+	var bucket *tokenBucket
+	for _, limit := range e.limits {
+		if limit.RequestsPerSecond > 0 && limit.matches(host) {
+			bucket = newTokenBucket(limit.RequestsPerSecond)
+			break
+		}
+	}
+	e.buckets[host] = bucket
+	return bucket
+}
+
+// rateLimitedTransport delays requests to the limited endpoints
+type rateLimitedTransport struct {
+	rt       http.RoundTripper
+	limiters *endpointLimiters
+}
+
+func (t *rateLimitedTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if bucket := t.limiters.forHost(request.URL.Hostname()); bucket != nil {
+		if err := bucket.Wait(request.Context()); err != nil {
+			return nil, err
+		}
+	}
+	return t.rt.RoundTrip(request)
+}
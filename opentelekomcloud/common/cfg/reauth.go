@@ -0,0 +1,62 @@
+package cfg
+
+import (
+	"fmt"
+	"log"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+)
+
+// canReauth checks if the token of the client authenticated with given options can be renewed.
+// User-provided token can't be renewed (unless it's used to assume an agency), and
+// AK/SK signed requests don't use tokens at all (unless it's used to assume an agency).
+// Agency chain is always assumed from the beginning, so its token can be renewed.
+func canReauth(ao golangsdk.AuthOptionsProvider) bool {
+	switch opts := ao.(type) {
+	case golangsdk.AuthOptions:
+		if opts.AgencyName != "" && opts.AgencyDomainName != "" {
+			return true
+		}
+		return opts.TokenID == ""
+	case golangsdk.AKSKAuthOptions:
+		return opts.AgencyName != "" && opts.AgencyDomainName != ""
+	case assumeRoleOptions:
+		return true
+	}
+	return false
+}
+
+// setupReauth makes the client transparently re-authenticate when a request fails with 401 because of
+// the expired token. Re-authentication is done under the client token lock, so concurrent requests
+// failed with the same expired token cause single re-authentication.
+func (c *Config) setupReauth(client *golangsdk.ProviderClient, ao golangsdk.AuthOptionsProvider) {
+	if !canReauth(ao) && c.tokenProcess == nil {
+		client.ReauthFunc = nil
+		return
+	}
+	client.UseTokenLock()
+	client.ReauthFunc = func() error {
+		log.Printf("[DEBUG] OpenTelekomCloud token is expired, re-authenticating")
+		ao := ao
+		if c.tokenProcess != nil {
+			// the token printed by the credential process is used to authenticate, so it's renewed first
+			c.tokenProcess.expireToken(client.TokenID)
+			var err error
+			if ao, err = c.withProcessToken(ao); err != nil {
+				return err
+			}
+		}
+		// Authentication is done using a separate client: requests sent by `client` during re-authentication
+		// don't get any token, while the agency authentication needs the token issued on the first step
+		fresh, err := c.newProviderClient(ao)
+		if err != nil {
+			return err
+		}
+		if err := authenticateClient(fresh, ao); err != nil {
+			return fmt.Errorf("error re-authenticating: %w", err)
+		}
+		// client.SetToken can't be used here as the token lock is held by the caller
+		client.TokenID = fresh.TokenID
+		return nil
+	}
+}
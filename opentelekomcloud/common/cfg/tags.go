@@ -0,0 +1,26 @@
+package cfg
+
+import (
+	"strings"
+)
+
+// IgnoreTags describes tags which are managed outside of terraform and should be ignored in resource tags
+type IgnoreTags struct {
+	Keys        []string
+	KeyPrefixes []string
+}
+
+// Ignored checks if the tag with given key should be ignored
+func (i IgnoreTags) Ignored(key string) bool {
+	for _, k := range i.Keys {
+		if key == k {
+			return true
+		}
+	}
+	for _, prefix := range i.KeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
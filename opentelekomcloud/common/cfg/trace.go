@@ -0,0 +1,209 @@
+package cfg
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+)
+
+// traceRecord is a single HTTP exchange written to the trace file
+type traceRecord struct {
+	Time       string  `json:"time"`
+	CallID     string  `json:"call_id"`
+	Resource   string  `json:"resource,omitempty"`
+	ResourceID string  `json:"resource_id,omitempty"`
+	Method     string  `json:"method"`
+	URL        string  `json:"url"`
+	Status     int     `json:"status,omitempty"`
+	LatencyMs  float64 `json:"latency_ms"`
+	Retries    int     `json:"retries"`
+	RequestID  string  `json:"request_id,omitempty"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// tracer writes one JSON line per HTTP exchange to the trace file
+type tracer struct {
+	path string
+
+	mut  sync.Mutex
+	file *os.File
+}
+
+// tracers are shared by path, so the file is opened once per process
+// even if it is used by several provider configurations
+var (
+	tracers   = make(map[string]*tracer)
+	tracerMut sync.Mutex
+)
+
+// openTracer returns the tracer appending to the file. Terraform starts the provider
+// several times during a single run, so the file is never truncated.
+func openTracer(path string) (*tracer, error) {
+	tracerMut.Lock()
+	defer tracerMut.Unlock()
+
+	if t, ok := tracers[path]; ok {
+		return t, nil
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("error opening trace file: %s", err)
+	}
+	t := &tracer{path: path, file: file}
+	tracers[path] = t
+	return t, nil
+}
+
+// CloseTracers closes all the opened trace files
+func CloseTracers() error {
+	tracerMut.Lock()
+	opened := make([]*tracer, 0, len(tracers))
+	for _, t := range tracers {
+		opened = append(opened, t)
+	}
+	tracerMut.Unlock()
+
+	var firstErr error
+	for _, t := range opened {
+		if err := t.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Close closes the trace file, exchanges made after closing are not traced
+func (t *tracer) Close() error {
+	tracerMut.Lock()
+	if tracers[t.path] == t {
+		delete(tracers, t.path)
+	}
+	tracerMut.Unlock()
+
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	if t.file == nil {
+		return nil
+	}
+	err := t.file.Close()
+	t.file = nil
+	return err
+}
+
+// newCallID generates the ID correlating the trace line with the debug log of the HTTP exchange
+func newCallID() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(id)
+}
+
+// requestID returns ID of the request assigned by the API gateway
+func requestID(response *http.Response) string {
+	if id := response.Header.Get("X-Request-Id"); id != "" {
+		return id
+	}
+	return response.Header.Get("X-Openstack-Request-Id")
+}
+
+func (t *tracer) trace(callID string, request *http.Request, response *http.Response, retries int, latency time.Duration, err error) {
+	resource := resourceFromContext(request.Context())
+	record := traceRecord{
+		Time:       time.Now().UTC().Format(time.RFC3339Nano),
+		CallID:     callID,
+		Resource:   resource.Type,
+		ResourceID: resource.ID,
+		Method:     request.Method,
+		URL:        request.URL.String(),
+		LatencyMs:  float64(latency) / float64(time.Millisecond),
+		Retries:    retries,
+	}
+	if response != nil {
+		record.Status = response.StatusCode
+		record.RequestID = requestID(response)
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	line, _ := json.Marshal(record)
+
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	if t.file == nil {
+		return
+	}
+	_, _ = t.file.Write(append(line, '\n'))
+}
+
+// tracedResource is the Terraform resource making the request
+type tracedResource struct {
+	Type string
+	ID   string
+}
+
+type tracedResourceKey struct{}
+
+func withResource(ctx context.Context, resource tracedResource) context.Context {
+	return context.WithValue(ctx, tracedResourceKey{}, resource)
+}
+
+func resourceFromContext(ctx context.Context) tracedResource {
+	resource, _ := ctx.Value(tracedResourceKey{}).(tracedResource)
+	return resource
+}
+
+// resourceTransport adds the resource to the context of every request sent
+type resourceTransport struct {
+	rt       http.RoundTripper
+	resource tracedResource
+}
+
+func (t *resourceTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	return t.rt.RoundTrip(request.WithContext(withResource(request.Context(), t.resource)))
+}
+
+// ForResource returns config with clients adding the type and ID of the Terraform resource to the trace
+// lines of the requests they send. Config is returned as is if there is no trace file.
+func (c *Config) ForResource(resourceType, id string) *Config {
+	resource := tracedResource{Type: resourceType, ID: id}
+	if c.tracer == nil || resource == c.resource {
+		return c
+	}
+	config := *c
+	config.resource = resource
+	config.HwClient = resourceClient(c.HwClient, resource)
+	config.DomainClient = resourceClient(c.DomainClient, resource)
+	return &config
+}
+
+// resourceClient returns copy of the provider client sending the requests in the context of the resource.
+// The copy shares the token lock with the source client and takes the token renewed by the source client.
+func resourceClient(client *golangsdk.ProviderClient, resource tracedResource) *golangsdk.ProviderClient {
+	if client == nil {
+		return nil
+	}
+	scoped := *client
+	scoped.HTTPClient.Transport = &resourceTransport{rt: client.HTTPClient.Transport, resource: resource}
+	if client.ReauthFunc != nil {
+		// called under the token lock shared with the source client
+		scoped.ReauthFunc = func() error {
+			if client.TokenID == scoped.TokenID {
+				if err := client.ReauthFunc(); err != nil {
+					return err
+				}
+			}
+			scoped.TokenID = client.TokenID
+			return nil
+		}
+	}
+	return &scoped
+}
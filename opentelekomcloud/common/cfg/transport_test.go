@@ -1,10 +1,17 @@
 package cfg
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
 	th "github.com/opentelekomcloud/gophertelekomcloud/testhelper"
@@ -82,3 +89,454 @@ func TestRoundTripperRetry(t *testing.T) {
 	th.CheckNoErr(t, err)
 	th.AssertEquals(t, failHandler.ExpectedFailures, failHandler.FailCount)
 }
+
+func TestRoundTripperRetryThrottled(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var requests int
+	th.Mux.HandleFunc("/throttled", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		th.AssertEquals(t, `{"key":"value"}`, string(body))
+		requests++
+		if requests < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	client := &http.Client{Transport: &RoundTripper{
+		Rt:         &http.Transport{},
+		MaxRetries: 2,
+	}}
+	resp, err := client.Post(th.Endpoint()+"throttled", "application/json", strings.NewReader(`{"key":"value"}`))
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, http.StatusOK, resp.StatusCode)
+	th.AssertEquals(t, 3, requests)
+}
+
+func TestRoundTripperRetryExhausted(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var requests int
+	th.Mux.HandleFunc("/unavailable", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	client := &http.Client{Transport: &RoundTripper{
+		Rt:         &http.Transport{},
+		MaxRetries: 1,
+	}}
+	resp, err := client.Get(th.Endpoint() + "unavailable")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, http.StatusServiceUnavailable, resp.StatusCode)
+	th.AssertEquals(t, 2, requests)
+}
+
+func TestRoundTripperNoRetryPostGatewayTimeout(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var requests int
+	th.Mux.HandleFunc("/create", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusGatewayTimeout)
+	})
+
+	client := &http.Client{Transport: &RoundTripper{
+		Rt:         &http.Transport{},
+		MaxRetries: 2,
+	}}
+	resp, err := client.Post(th.Endpoint()+"create", "application/json", strings.NewReader(`{"key":"value"}`))
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, http.StatusGatewayTimeout, resp.StatusCode)
+	th.AssertEquals(t, 1, requests)
+
+	// idempotent requests are retried
+	requests = 0
+	request, _ := http.NewRequest(http.MethodPut, th.Endpoint()+"create", strings.NewReader(`{"key":"value"}`))
+	resp, err = client.Do(request)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, http.StatusGatewayTimeout, resp.StatusCode)
+	th.AssertEquals(t, 3, requests)
+}
+
+type unbufferedBody struct {
+	io.Reader
+}
+
+func (unbufferedBody) Close() error { return nil }
+
+func TestRoundTripperNoReplayUnbufferedBody(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var requests int
+	th.Mux.HandleFunc("/unbuffered", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	client := &http.Client{Transport: &RoundTripper{
+		Rt:         &http.Transport{},
+		MaxRetries: 3,
+	}}
+	req, err := http.NewRequest(http.MethodPost, th.Endpoint()+"unbuffered", unbufferedBody{strings.NewReader("data")})
+	th.AssertNoErr(t, err)
+	resp, err := client.Do(req)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, http.StatusBadGateway, resp.StatusCode)
+	th.AssertEquals(t, 1, requests)
+}
+
+func TestRetryAfter(t *testing.T) {
+	cases := map[string]time.Duration{
+		"":                              0,
+		"5":                             5 * time.Second,
+		"-5":                            0,
+		"invalid":                       0,
+		"100000":                        maxTimeout,
+		"Wed, 21 Oct 2015 07:28:00 GMT": 0,
+	}
+	for value, expected := range cases {
+		resp := &http.Response{Header: http.Header{}}
+		resp.Header.Set("Retry-After", value)
+		th.AssertEquals(t, expected, retryAfter(resp))
+	}
+}
+
+func TestRateLimitedTransport(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/limited", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	client := &http.Client{Transport: &rateLimitedTransport{
+		rt: &http.Transport{},
+		limiters: newEndpointLimiters([]RateLimit{
+			{Service: "127.0.0.1", RequestsPerSecond: 10},
+		}),
+	}}
+
+	start := time.Now()
+	for i := 0; i < 15; i++ {
+		resp, err := client.Get(th.Endpoint() + "limited")
+		th.AssertNoErr(t, err)
+		th.AssertEquals(t, http.StatusOK, resp.StatusCode)
+	}
+	// first 10 requests use the burst, next 5 are sent at 10 requests per second
+	elapsed := time.Since(start)
+	if elapsed < 400*time.Millisecond {
+		t.Errorf("requests were not rate limited, elapsed time: %s", elapsed)
+	}
+}
+
+func TestEndpointLimitersMatching(t *testing.T) {
+	limiters := newEndpointLimiters([]RateLimit{
+		{Service: "vpc", RequestsPerSecond: 5},
+		{Service: "elb.eu-de.otc.t-systems.com", RequestsPerSecond: 1},
+	})
+
+	vpc := limiters.forHost("vpc.eu-de.otc.t-systems.com")
+	th.AssertEquals(t, true, vpc != nil)
+	th.AssertEquals(t, vpc, limiters.forHost("vpc.eu-de.otc.t-systems.com"))
+	th.AssertEquals(t, true, limiters.forHost("elb.eu-de.otc.t-systems.com") != nil)
+	th.AssertEquals(t, true, limiters.forHost("ecs.eu-de.otc.t-systems.com") == nil)
+	th.AssertEquals(t, true, limiters.forHost("vpcep.eu-de.otc.t-systems.com") == nil)
+}
+
+func TestCassetteRecordReplay(t *testing.T) {
+	th.SetupHTTP()
+
+	th.Mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Subject-Token", "secret-token")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, `{"token":{"catalog":[]}}`)
+	})
+	states := []string{"CREATING", "ACTIVE"}
+	th.Mux.HandleFunc("/vpcs/vpc-id", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"status":"%s"}`, states[0])
+		states = states[1:]
+	})
+
+	dir, err := ioutil.TempDir("", "cassette")
+	th.AssertNoErr(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	path := filepath.Join(dir, "cassette.jsonl")
+	// interactions of the previous recording are dropped
+	stale := `{"request":{"method":"GET","url":"` + th.Endpoint() + `vpcs/vpc-id"},"response":{"status_code":200,"body":"stale"}}`
+	th.AssertNoErr(t, ioutil.WriteFile(path, []byte(stale+"\n"), 0600))
+
+	recorder, err := openCassette(path, modeRecord)
+	th.AssertNoErr(t, err)
+	rt := &RoundTripper{Rt: http.DefaultTransport, Cassette: recorder}
+	client := http.Client{Transport: rt}
+
+	authBody := `{"auth": {"identity": {"password": {"user": {"password": "secret-password"}}}}}`
+	request, _ := http.NewRequest("POST", th.Endpoint()+"v3/auth/tokens", strings.NewReader(authBody))
+	request.Header.Set("Content-Type", "application/json")
+	recorded := []string{doRequest(t, client, request)}
+	for i := 0; i < 2; i++ {
+		request, _ = http.NewRequest("GET", th.Endpoint()+"vpcs/vpc-id", nil)
+		recorded = append(recorded, doRequest(t, client, request))
+	}
+	th.TeardownHTTP()
+	th.AssertNoErr(t, recorder.Close())
+
+	cassette, err := ioutil.ReadFile(path)
+	th.AssertNoErr(t, err)
+	if strings.Contains(string(cassette), "secret-password") || strings.Contains(string(cassette), "secret-token") {
+		t.Fatalf("cassette contains secrets: %s", cassette)
+	}
+
+	player, err := openCassette(path, modeReplay)
+	th.AssertNoErr(t, err)
+	defer func() { _ = player.Close() }()
+	client = http.Client{Transport: &RoundTripper{Rt: http.DefaultTransport, Cassette: player}}
+
+	request, _ = http.NewRequest("POST", th.Endpoint()+"v3/auth/tokens", strings.NewReader(authBody))
+	th.AssertEquals(t, recorded[0], doRequest(t, client, request))
+	for i := 0; i < 2; i++ {
+		request, _ = http.NewRequest("GET", th.Endpoint()+"vpcs/vpc-id", nil)
+		th.AssertEquals(t, recorded[i+1], doRequest(t, client, request))
+	}
+
+	request, _ = http.NewRequest("GET", th.Endpoint()+"vpcs/vpc-id", nil)
+	_, err = client.Do(request)
+	if err == nil {
+		t.Fatal("error expected for not recorded interaction")
+	}
+}
+
+func doRequest(t *testing.T, client http.Client, request *http.Request) string {
+	response, err := client.Do(request)
+	th.AssertNoErr(t, err)
+	defer func() { _ = response.Body.Close() }()
+	body, err := ioutil.ReadAll(response.Body)
+	th.AssertNoErr(t, err)
+	return fmt.Sprintf("%d %s %s", response.StatusCode, response.Header.Get("Content-Type"), body)
+}
+
+func TestRoundTripperTrace(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var requests int
+	th.Mux.HandleFunc("/traced", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-Request-Id", fmt.Sprintf("request-%d", requests))
+		if requests < 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	dir, err := ioutil.TempDir("", "trace")
+	th.AssertNoErr(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	path := filepath.Join(dir, "trace.jsonl")
+	tr, err := openTracer(path)
+	th.AssertNoErr(t, err)
+
+	client := &http.Client{Transport: &RoundTripper{
+		Rt:         &http.Transport{},
+		MaxRetries: 2,
+		tracer:     tr,
+	}}
+	resp, err := client.Get(th.Endpoint() + "traced")
+	th.AssertNoErr(t, err)
+	_ = resp.Body.Close()
+	resp, err = client.Get(th.Endpoint() + "traced")
+	th.AssertNoErr(t, err)
+	_ = resp.Body.Close()
+	th.AssertNoErr(t, tr.Close())
+
+	// closed tracer doesn't fail the exchange
+	resp, err = client.Get(th.Endpoint() + "traced")
+	th.AssertNoErr(t, err)
+	_ = resp.Body.Close()
+
+	data, err := ioutil.ReadFile(path)
+	th.AssertNoErr(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	th.AssertEquals(t, 2, len(lines))
+
+	var record traceRecord
+	th.AssertNoErr(t, json.Unmarshal([]byte(lines[0]), &record))
+	th.AssertEquals(t, "GET", record.Method)
+	th.AssertEquals(t, th.Endpoint()+"traced", record.URL)
+	th.AssertEquals(t, http.StatusOK, record.Status)
+	th.AssertEquals(t, 1, record.Retries)
+	th.AssertEquals(t, "request-2", record.RequestID)
+	th.AssertEquals(t, 16, len(record.CallID))
+
+	var next traceRecord
+	th.AssertNoErr(t, json.Unmarshal([]byte(lines[1]), &next))
+	th.AssertEquals(t, 0, next.Retries)
+	th.AssertEquals(t, "request-3", next.RequestID)
+	if next.CallID == record.CallID {
+		t.Fatalf("call ID %s is reused", record.CallID)
+	}
+}
+
+func TestConfigForResource(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/resource", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Auth-Token") != "token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	dir, err := ioutil.TempDir("", "trace")
+	th.AssertNoErr(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	path := filepath.Join(dir, "trace.jsonl")
+	tr, err := openTracer(path)
+	th.AssertNoErr(t, err)
+	defer func() { _ = tr.Close() }()
+
+	client := &golangsdk.ProviderClient{
+		HTTPClient: http.Client{Transport: &RoundTripper{Rt: &http.Transport{}, tracer: tr}},
+	}
+	client.UseTokenLock()
+	client.SetToken("token-1")
+	reauths := 0
+	client.ReauthFunc = func() error {
+		reauths++
+		client.TokenID = fmt.Sprintf("token-%d", reauths+1)
+		return nil
+	}
+	config := &Config{HwClient: client, tracer: tr}
+
+	scoped := config.ForResource("opentelekomcloud_vpc_v1", "vpc-id")
+	th.AssertEquals(t, scoped, scoped.ForResource("opentelekomcloud_vpc_v1", "vpc-id"))
+	_, err = scoped.HwClient.Request("GET", th.Endpoint()+"resource", &golangsdk.RequestOpts{})
+	th.AssertNoErr(t, err)
+	// the token renewed by the scoped client is used by the source client
+	th.AssertEquals(t, "token-2", client.Token())
+	_, err = client.Request("GET", th.Endpoint()+"resource", &golangsdk.RequestOpts{})
+	th.AssertNoErr(t, err)
+	// the token renewed by the source client is taken without authenticating again
+	other := config.ForResource("opentelekomcloud_vpc_subnet_v1", "")
+	other.HwClient.TokenID = "token-1"
+	_, err = other.HwClient.Request("GET", th.Endpoint()+"resource", &golangsdk.RequestOpts{})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, reauths)
+
+	data, err := ioutil.ReadFile(path)
+	th.AssertNoErr(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	th.AssertEquals(t, 5, len(lines))
+	expected := []struct {
+		resource string
+		id       string
+		status   int
+	}{
+		{"opentelekomcloud_vpc_v1", "vpc-id", http.StatusUnauthorized},
+		{"opentelekomcloud_vpc_v1", "vpc-id", http.StatusOK},
+		{"", "", http.StatusOK},
+		{"opentelekomcloud_vpc_subnet_v1", "", http.StatusUnauthorized},
+		{"opentelekomcloud_vpc_subnet_v1", "", http.StatusOK},
+	}
+	for i, e := range expected {
+		var record traceRecord
+		th.AssertNoErr(t, json.Unmarshal([]byte(lines[i]), &record))
+		th.AssertEquals(t, e.resource, record.Resource)
+		th.AssertEquals(t, e.id, record.ResourceID)
+		th.AssertEquals(t, e.status, record.Status)
+	}
+
+	// config without the trace file is not copied
+	untraced := &Config{HwClient: client}
+	th.AssertEquals(t, untraced, untraced.ForResource("opentelekomcloud_vpc_v1", "vpc-id"))
+}
+
+func TestMaskJSON(t *testing.T) {
+	body := `{
+  "auth": {"identity": {"methods": ["password"], "password": {"user": {"name": "user", "password": "top-secret"}}}},
+  "token_auth": {"identity": {"methods": ["token"], "token": {"id": "top-secret"}}},
+  "totp": {"user": {"id": "user-id", "passcode": "top-secret"}},
+  "db": {"type": "PostgreSQL", "password": "top-secret"},
+  "instances": [{"name": "css", "admin_pass": "top-secret"}, {"name": "ecs", "adminPass": "top-secret"}],
+  "ipsecpolicy": {"psk": "top-secret"},
+  "credential": {"access": "top-secret", "secret": "top-secret", "securitytoken": "top-secret"},
+  "access": "public",
+  "custom": {"token_value": "top-secret"}
+}`
+	var data interface{}
+	th.AssertNoErr(t, json.Unmarshal([]byte(body), &data))
+	keys := append(append([]string{}, DefaultSensitiveKeys...), "custom.token_value")
+	maskJSON(data, nil, keys)
+
+	masked, err := json.Marshal(data)
+	th.AssertNoErr(t, err)
+	if strings.Contains(string(masked), "top-secret") {
+		t.Fatalf("secrets are not masked: %s", masked)
+	}
+	th.AssertJSONEquals(t, `{
+  "auth": {"identity": {"methods": ["password"], "password": {"user": {"name": "user", "password": "***"}}}},
+  "token_auth": {"identity": {"methods": ["token"], "token": {"id": "***"}}},
+  "totp": {"user": {"id": "user-id", "passcode": "***"}},
+  "db": {"type": "PostgreSQL", "password": "***"},
+  "instances": [{"name": "css", "admin_pass": "***"}, {"name": "ecs", "adminPass": "***"}],
+  "ipsecpolicy": {"psk": "***"},
+  "credential": {"access": "***", "secret": "***", "securitytoken": "***"},
+  "access": "public",
+  "custom": {"token_value": "***"}
+}`, data)
+}
+
+func TestKeyMatches(t *testing.T) {
+	th.AssertEquals(t, true, keyMatches([]string{"db", "password"}, "password"))
+	th.AssertEquals(t, true, keyMatches([]string{"db", "password"}, "db.password"))
+	th.AssertEquals(t, true, keyMatches([]string{"instance", "db", "Password"}, "DB.password"))
+	th.AssertEquals(t, false, keyMatches([]string{"user", "password"}, "db.password"))
+	th.AssertEquals(t, false, keyMatches([]string{"password"}, "db.password"))
+	th.AssertEquals(t, false, keyMatches([]string{"access"}, "credential.access"))
+}
+
+func TestMaskBody(t *testing.T) {
+	keys := DefaultSensitiveKeys
+
+	form := maskBody([]byte("name=user&password=secret&psk=secret"), "application/x-www-form-urlencoded", keys)
+	th.AssertEquals(t, "name=user&password=%2A%2A%2A&psk=%2A%2A%2A", form)
+
+	text := maskBody([]byte("user=admin password=secret, admin_pass: \"secret\""), "text/plain", keys)
+	th.AssertEquals(t, "user=admin password=***, admin_pass: \"***\"", text)
+
+	xml := maskBody([]byte("<user><name>admin</name><password>secret</password></user>"), "application/xml", keys)
+	th.AssertEquals(t, "<user><name>admin</name><password>***</password></user>", xml)
+
+	jsonBody := maskBody([]byte(`[{"password":"secret"}]`), "", keys)
+	th.AssertEquals(t, `[{"password":"***"}]`, jsonBody)
+}
+
+func TestFormatJSONMasking(t *testing.T) {
+	lrt := &RoundTripper{SensitiveKeys: []string{"api_key"}}
+	formatted := lrt.formatJSON([]byte(`{"db":{"password":"secret"},"api_key":"secret","name":"test"}`))
+	if strings.Contains(formatted, "secret") {
+		t.Fatalf("secrets are not masked: %s", formatted)
+	}
+	if !strings.Contains(formatted, `"name": "test"`) {
+		t.Fatalf("unexpected formatted body: %s", formatted)
+	}
+}
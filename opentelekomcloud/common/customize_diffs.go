@@ -60,24 +60,10 @@ func ValidateVolumeType(argName string) schema.CustomizeDiffFunc {
 			return nil
 		}
 		config := meta.(*cfg.Config)
-		client, err := config.BlockStorageV3Client(config.GetRegion(d))
-		if err != nil {
-			return fmt.Errorf("error creating blockstorage v3 client: %s", err)
-		}
-
-		pages, err := volumetypes.List(client).AllPages()
-		if err != nil {
-			return fmt.Errorf("error retrieving volume types: %s", err)
-		}
-		types, err := volumetypes.ExtractVolumeTypes(pages)
+		typeAZs, err := volumeTypeAZs(config, config.GetRegion(d))
 		if err != nil {
 			return err
 		}
-		typeAZs := make(map[string][]string) // map of type name (lower case) -> az list
-		for _, volumeType := range types {
-			typeName := strings.ToLower(volumeType.Name)
-			typeAZs[typeName] = getZonesFromVolumeType(volumeType)
-		}
 
 		if !strings.Contains(argName, ".*") {
 			return checkVolumeTypeAvailable(d, argName, expectedAZ, typeAZs)
@@ -96,6 +82,35 @@ func ValidateVolumeType(argName string) schema.CustomizeDiffFunc {
 	}
 }
 
+// volumeTypeAZs returns map of volume type name (lower case) -> az list, the map is cached for the run
+func volumeTypeAZs(config *cfg.Config, region string) (map[string][]string, error) {
+	typeAZs, err := config.CachedLookup("volume-types/"+region, func() (interface{}, error) {
+		client, err := config.BlockStorageV3Client(region)
+		if err != nil {
+			return nil, fmt.Errorf("error creating blockstorage v3 client: %s", err)
+		}
+
+		pages, err := volumetypes.List(client).AllPages()
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving volume types: %s", err)
+		}
+		types, err := volumetypes.ExtractVolumeTypes(pages)
+		if err != nil {
+			return nil, err
+		}
+		typeAZs := make(map[string][]string)
+		for _, volumeType := range types {
+			typeName := strings.ToLower(volumeType.Name)
+			typeAZs[typeName] = getZonesFromVolumeType(volumeType)
+		}
+		return typeAZs, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return typeAZs.(map[string][]string), nil
+}
+
 func getZonesFromVolumeType(t volumetypes.VolumeType) []string {
 	zonesStr := t.ExtraSpecs["RESKEY:availability_zones"].(string)
 	return strings.Split(zonesStr, ",")
@@ -111,11 +126,15 @@ func ValidateVPC(argName string) schema.CustomizeDiffFunc {
 			return nil
 		}
 		config := meta.(*cfg.Config)
-		vpcClient, err := config.NetworkingV1Client(config.GetRegion(d))
+		region := config.GetRegion(d)
+		_, err := config.CachedLookup(fmt.Sprintf("vpc/%s/%s", region, vpcID), func() (interface{}, error) {
+			vpcClient, err := config.NetworkingV1Client(region)
+			if err != nil {
+				return nil, fmt.Errorf("error creating opentelekomcloud CCE Client: %s", err)
+			}
+			return vpcs.Get(vpcClient, vpcID.(string)).Extract()
+		})
 		if err != nil {
-			return fmt.Errorf("error creating opentelekomcloud CCE Client: %s", err)
-		}
-		if err := vpcs.Get(vpcClient, vpcID.(string)).Err; err != nil {
 			return fmt.Errorf("can't find VPC `%s`: %s", vpcID, err)
 		}
 		return nil
@@ -132,11 +151,15 @@ func ValidateSubnet(argName string) schema.CustomizeDiffFunc {
 			return nil
 		}
 		config := meta.(*cfg.Config)
-		subnetClient, err := config.NetworkingV1Client(config.GetRegion(d))
+		region := config.GetRegion(d)
+		_, err := config.CachedLookup(fmt.Sprintf("subnet/%s/%s", region, subnetId), func() (interface{}, error) {
+			subnetClient, err := config.NetworkingV1Client(region)
+			if err != nil {
+				return nil, fmt.Errorf("error creating opentelekomcloud CCE Client: %s", err)
+			}
+			return subnets.Get(subnetClient, subnetId.(string)).Extract()
+		})
 		if err != nil {
-			return fmt.Errorf("error creating opentelekomcloud CCE Client: %s", err)
-		}
-		if err := subnets.Get(subnetClient, subnetId.(string)).Err; err != nil {
 			return fmt.Errorf("can't find Subnet `%s`: %s", subnetId, err)
 		}
 		return nil
@@ -25,7 +25,9 @@ var Descriptions = map[string]string{
 
 	"token": "Authentication token to use as an alternative to username/password.",
 
-	"security_token": "Security token to use for OBS federated authentication.",
+	"security_token": "Security token of the temporary AK/SK, used for API requests and OBS federated authentication.",
+
+	"credential_process": "External command printing JSON with AK/SK or token used for authentication.",
 
 	"domain_id": "The ID of the Domain to scope to (Identity v3).",
 
@@ -50,9 +52,24 @@ var Descriptions = map[string]string{
 
 	"delegated_project": "The name of delegated project (Identity v3).",
 
+	"assume_role": "Agencies assumed one after another, each using the token of the previous one.",
+
 	"cloud": "An entry in a `clouds.yaml` file to use.",
 
-	"max_retries": "How many times HTTP connection should be retried until giving up.",
+	"max_retries": "How many times HTTP request should be retried on connection errors\n" +
+		"or throttling responses until giving up.",
 
 	"passcode": "One-time MFA passcode",
+
+	"default_tags": "Tags applied to all taggable resources. Resource tags win on conflicts.",
+
+	"ignore_tags": "Tags managed outside of terraform which are ignored in all resources.",
+
+	"endpoints": "Custom endpoints of the services used instead of the ones from the service catalog.",
+
+	"sensitive_keys": "Additional keys of request and response body fields masked in debug logs.",
+
+	"trace_file": "Path of the file where every HTTP exchange is written to as a JSON line.",
+
+	"rate_limit": "Client-side limit of requests per second sent to the service endpoint.",
 }
@@ -0,0 +1,165 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/cfg"
+)
+
+// Flavor statuses which don't allow to create new instances
+var unavailableFlavorStatuses = []string{"abandon", "sellout"}
+
+// ecsFlavor is the ECS flavor with the sale status
+type ecsFlavor struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	OsExtraSpecs struct {
+		// Status is the status of the flavor in the AZs missing in the AZStatuses
+		Status string `json:"cond:operation:status"`
+		// AZStatuses is the list of AZ statuses, e.g. `eu-de-01(normal),eu-de-02(sellout)`
+		AZStatuses string `json:"cond:operation:az"`
+	} `json:"os_extra_specs"`
+}
+
+// statusIn returns the status of the flavor in the AZ
+func (f ecsFlavor) statusIn(az string) string {
+	for _, azStatus := range strings.Split(f.OsExtraSpecs.AZStatuses, ",") {
+		azStatus = strings.TrimSpace(azStatus)
+		if strings.HasPrefix(azStatus, az+"(") && strings.HasSuffix(azStatus, ")") {
+			return strings.TrimSuffix(strings.TrimPrefix(azStatus, az+"("), ")")
+		}
+	}
+	return f.OsExtraSpecs.Status
+}
+
+// availableAZs returns sorted list of AZs where the flavor is available
+func (f ecsFlavor) availableAZs(azs []string) []string {
+	var available []string
+	for _, az := range azs {
+		if !StringInSlice(f.statusIn(az), unavailableFlavorStatuses) {
+			available = append(available, az)
+		}
+	}
+	sort.Strings(available)
+	return available
+}
+
+// ecsFlavors returns ECS flavors of the region, the list is loaded once per run
+func ecsFlavors(config *cfg.Config, region string) ([]ecsFlavor, error) {
+	flavors, err := config.CachedLookup("ecs-flavors/"+region, func() (interface{}, error) {
+		client, err := config.ComputeV1Client(region)
+		if err != nil {
+			return nil, fmt.Errorf("error creating OpenTelekomCloud ComputeV1 client: %s", err)
+		}
+		var result struct {
+			Flavors []ecsFlavor `json:"flavors"`
+		}
+		if _, err := client.Get(client.ServiceURL("cloudservers", "flavors"), &result, nil); err != nil {
+			return nil, fmt.Errorf("error retrieving ECS flavors: %s", err)
+		}
+		return result.Flavors, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return flavors.([]ecsFlavor), nil
+}
+
+// flavorAZs returns all AZs mentioned in the flavor statuses
+func flavorAZs(flavors []ecsFlavor) []string {
+	var azs []string
+	for _, flavor := range flavors {
+		for _, azStatus := range strings.Split(flavor.OsExtraSpecs.AZStatuses, ",") {
+			az := strings.TrimSpace(strings.SplitN(azStatus, "(", 2)[0])
+			if az != "" && !StringInSlice(az, azs) {
+				azs = append(azs, az)
+			}
+		}
+	}
+	return azs
+}
+
+func checkFlavorAvailable(flavors []ecsFlavor, flavorRef, expectedAZ string) error {
+	var flavor *ecsFlavor
+	for i := range flavors {
+		if flavors[i].ID == flavorRef || flavors[i].Name == flavorRef {
+			flavor = &flavors[i]
+			break
+		}
+	}
+	if flavor == nil {
+		// BMS and other special flavors are not listed with ECS flavors
+		log.Printf("[WARN] Flavor `%s` is not found in ECS flavors, availability is not validated", flavorRef)
+		return nil
+	}
+
+	azs := flavorAZs(flavors)
+	validAZs := flavor.availableAZs(azs)
+	if expectedAZ == "" || expectedAZ == "random" {
+		unavailable := len(validAZs) == 0
+		if len(azs) == 0 {
+			unavailable = StringInSlice(flavor.OsExtraSpecs.Status, unavailableFlavorStatuses)
+		}
+		if unavailable {
+			return fmt.Errorf("flavor `%s` is not available in any AZ", flavorRef)
+		}
+		return nil
+	}
+	if status := flavor.statusIn(expectedAZ); StringInSlice(status, unavailableFlavorStatuses) {
+		return fmt.Errorf(
+			"flavor `%s` is not available in AZ `%s`, status: %s.\nAvailable in AZs: %v",
+			flavorRef, expectedAZ, status, validAZs,
+		)
+	}
+	return nil
+}
+
+// ValidateFlavor checks that ECS flavor set in the first non-empty of `flavorArgs` is available
+// in AZ set in `azArg`. If `azArg` is empty or AZ is not set, the flavor has to be available in any AZ.
+// Flavors missing in the list of ECS flavors are not validated.
+func ValidateFlavor(azArg string, flavorArgs ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		// existing instances are validated only when they are moved or resized
+		if d.Id() != "" && !hasAnyChange(d, append(flavorArgs, azArg)...) {
+			return nil
+		}
+		var flavorRef string
+		for _, arg := range flavorArgs {
+			if v, ok := d.GetOk(arg); ok {
+				flavorRef = v.(string)
+				break
+			}
+		}
+		if flavorRef == "" {
+			return nil
+		}
+		var expectedAZ string
+		if azArg != "" {
+			expectedAZ = d.Get(azArg).(string)
+		}
+
+		config := meta.(*cfg.Config)
+		flavors, err := ecsFlavors(config, config.GetRegion(d))
+		if err != nil {
+			// don't fail the plan if flavors can't be listed, e.g. because of the missing permissions
+			log.Printf("[WARN] Flavors are not validated: %s", err)
+			return nil
+		}
+		return checkFlavorAvailable(flavors, flavorRef, expectedAZ)
+	}
+}
+
+func hasAnyChange(d *schema.ResourceDiff, keys ...string) bool {
+	for _, key := range keys {
+		if key != "" && d.HasChange(key) {
+			return true
+		}
+	}
+	return false
+}
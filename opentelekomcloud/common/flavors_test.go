@@ -0,0 +1,114 @@
+package common
+
+import (
+	"testing"
+
+	th "github.com/opentelekomcloud/gophertelekomcloud/testhelper"
+)
+
+func testFlavor(id, status, azStatuses string) ecsFlavor {
+	flavor := ecsFlavor{ID: id, Name: id + "-name"}
+	flavor.OsExtraSpecs.Status = status
+	flavor.OsExtraSpecs.AZStatuses = azStatuses
+	return flavor
+}
+
+func TestFlavorStatusIn(t *testing.T) {
+	cases := []struct {
+		name       string
+		status     string
+		azStatuses string
+		az         string
+		expected   string
+	}{
+		{"listed", "normal", "eu-de-01(normal),eu-de-02(sellout)", "eu-de-02", "sellout"},
+		{"spaces", "normal", "eu-de-01(normal), eu-de-02(abandon) ", "eu-de-02", "abandon"},
+		{"missing", "sellout", "eu-de-01(normal)", "eu-de-03", "sellout"},
+		{"empty", "normal", "", "eu-de-01", "normal"},
+		{"prefix", "normal", "eu-de-010(sellout)", "eu-de-01", "normal"},
+		{"malformed", "normal", "eu-de-01(sellout", "eu-de-01", "normal"},
+		{"empty status", "normal", "eu-de-01()", "eu-de-01", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			th.AssertEquals(t, c.expected, testFlavor("s2.medium.1", c.status, c.azStatuses).statusIn(c.az))
+		})
+	}
+}
+
+func TestFlavorAZs(t *testing.T) {
+	cases := []struct {
+		name     string
+		flavors  []ecsFlavor
+		expected []string
+	}{
+		{"none", nil, nil},
+		{"no statuses", []ecsFlavor{testFlavor("s2.medium.1", "normal", "")}, nil},
+		{
+			"deduplicated",
+			[]ecsFlavor{
+				testFlavor("s2.medium.1", "normal", "eu-de-01(normal),eu-de-02(sellout)"),
+				testFlavor("s3.medium.1", "normal", " eu-de-02(normal), eu-de-03(normal)"),
+			},
+			[]string{"eu-de-01", "eu-de-02", "eu-de-03"},
+		},
+		{"malformed", []ecsFlavor{testFlavor("s2.medium.1", "normal", "eu-de-01,,eu-de-02(normal")}, []string{"eu-de-01", "eu-de-02"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			th.AssertDeepEquals(t, c.expected, flavorAZs(c.flavors))
+		})
+	}
+}
+
+func TestCheckFlavorAvailable(t *testing.T) {
+	flavors := []ecsFlavor{
+		testFlavor("s2.medium.1", "normal", "eu-de-01(normal),eu-de-02(sellout)"),
+		testFlavor("s2.large.1", "normal", "eu-de-01(abandon),eu-de-02(sellout),eu-de-03(sellout)"),
+		testFlavor("s3.medium.1", "sellout", "eu-de-03(normal)"),
+	}
+	noAZs := []ecsFlavor{
+		testFlavor("s2.medium.1", "normal", ""),
+		testFlavor("s2.large.1", "sellout", ""),
+	}
+
+	cases := []struct {
+		name    string
+		flavors []ecsFlavor
+		ref     string
+		az      string
+		err     string
+	}{
+		{"by ID", flavors, "s2.medium.1", "eu-de-01", ""},
+		{"by name", flavors, "s2.medium.1-name", "eu-de-01", ""},
+		{"not listed", flavors, "physical.s4.medium", "eu-de-01", ""},
+		{
+			"sold out in AZ", flavors, "s2.medium.1", "eu-de-02",
+			"flavor `s2.medium.1` is not available in AZ `eu-de-02`, status: sellout.\nAvailable in AZs: [eu-de-01 eu-de-03]",
+		},
+		{"default status", flavors, "s3.medium.1", "eu-de-03", ""},
+		{
+			"default status sold out", flavors, "s3.medium.1", "eu-de-01",
+			"flavor `s3.medium.1` is not available in AZ `eu-de-01`, status: sellout.\nAvailable in AZs: [eu-de-03]",
+		},
+		{"any AZ", flavors, "s2.medium.1", "", ""},
+		{"random AZ", flavors, "s3.medium.1", "random", ""},
+		{"no AZ", flavors, "s2.large.1", "", "flavor `s2.large.1` is not available in any AZ"},
+		{"no AZs listed", noAZs, "s2.medium.1", "", ""},
+		{"no AZs listed sold out", noAZs, "s2.large.1", "", "flavor `s2.large.1` is not available in any AZ"},
+		{"unknown AZ", noAZs, "s2.medium.1", "eu-de-01", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := checkFlavorAvailable(c.flavors, c.ref, c.az)
+			if c.err == "" {
+				th.AssertNoErr(t, err)
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error: %s", c.err)
+			}
+			th.AssertEquals(t, c.err, err.Error())
+		})
+	}
+}
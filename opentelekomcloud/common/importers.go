@@ -16,6 +16,9 @@ func ImportAsManaged(_ context.Context, d *schema.ResourceData, _ interface{}) (
 // ImportByPath can be used to import resource by complex ID
 // (e.g. identity protocol by `<provider>/<identity>` or CCE addon by `<cluster_id>/<addon_id>`)
 //
+// Attribute `id` is special: that part of the path becomes the resource ID
+// (e.g. LB member by `<pool_id>/<member_id>` is imported using `"pool_id", "id"`)
+//
 // Usage in schema:
 //   StateContext: common.ImportByPath("provider", "protocol"),
 func ImportByPath(attributes ...string) schema.StateContextFunc {
@@ -29,6 +32,10 @@ func ImportByPath(attributes ...string) schema.StateContextFunc {
 		}
 
 		for i, attr := range attributes {
+			if attr == "id" {
+				d.SetId(parts[i])
+				continue
+			}
 			_ = d.Set(attr, parts[i])
 		}
 		return schema.ImportStatePassthroughContext(ctx, d, meta)
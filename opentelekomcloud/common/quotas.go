@@ -0,0 +1,197 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/blockstorage/extensions/quotasets"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/extensions/limits"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/flavors"
+
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/cfg"
+)
+
+// Names of the quotas
+const (
+	QuotaInstances          = "instances"
+	QuotaCores              = "cores"
+	QuotaRAM                = "ram"
+	QuotaVolumes            = "volumes"
+	QuotaGigabytes          = "gigabytes"
+	QuotaVPCs               = "vpcs"
+	QuotaSubnets            = "subnets"
+	QuotaEIPs               = "eips"
+	QuotaSecurityGroups     = "security_groups"
+	QuotaSecurityGroupRules = "security_group_rules"
+)
+
+// vpcQuotaTypes maps VPC quota types to the quota names
+var vpcQuotaTypes = map[string]string{
+	"vpc":               QuotaVPCs,
+	"subnet":            QuotaSubnets,
+	"publicIp":          QuotaEIPs,
+	"securityGroup":     QuotaSecurityGroups,
+	"securityGroupRule": QuotaSecurityGroupRules,
+}
+
+// NewQuotaLoader returns function loading quotas of ECS, EVS and VPC of the project
+func NewQuotaLoader(config *cfg.Config) cfg.QuotaLoader {
+	return func(region string) (map[string]cfg.Quota, error) {
+		quotas := make(map[string]cfg.Quota)
+
+		computeClient, err := config.ComputeV2Client(region)
+		if err != nil {
+			return nil, fmt.Errorf("error creating OpenTelekomCloud ComputeV2 client: %s", err)
+		}
+		computeLimits, err := limits.Get(computeClient, nil).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving compute limits: %s", err)
+		}
+		absolute := computeLimits.Absolute
+		quotas[QuotaInstances] = cfg.Quota{Used: absolute.TotalInstancesUsed, Limit: absolute.MaxTotalInstances}
+		quotas[QuotaCores] = cfg.Quota{Used: absolute.TotalCoresUsed, Limit: absolute.MaxTotalCores}
+		quotas[QuotaRAM] = cfg.Quota{Used: absolute.TotalRAMUsed, Limit: absolute.MaxTotalRAMSize}
+
+		blockStorageClient, err := config.BlockStorageV2Client(region)
+		if err != nil {
+			return nil, fmt.Errorf("error creating OpenTelekomCloud BlockStorageV2 client: %s", err)
+		}
+		volumeQuotas, err := quotasets.GetUsage(blockStorageClient, blockStorageClient.ProjectID).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving volume quotas: %s", err)
+		}
+		quotas[QuotaVolumes] = cfg.Quota{Used: volumeQuotas.Volumes.InUse, Limit: volumeQuotas.Volumes.Limit}
+		quotas[QuotaGigabytes] = cfg.Quota{Used: volumeQuotas.Gigabytes.InUse, Limit: volumeQuotas.Gigabytes.Limit}
+
+		networkingClient, err := config.NetworkingV1Client(region)
+		if err != nil {
+			return nil, fmt.Errorf("error creating OpenTelekomCloud NetworkingV1 client: %s", err)
+		}
+		var vpcQuotas struct {
+			Quotas struct {
+				Resources []struct {
+					Type  string `json:"type"`
+					Used  int    `json:"used"`
+					Quota int    `json:"quota"`
+				} `json:"resources"`
+			} `json:"quotas"`
+		}
+		if _, err := networkingClient.Get(networkingClient.ServiceURL("quotas"), &vpcQuotas, nil); err != nil {
+			return nil, fmt.Errorf("error retrieving VPC quotas: %s", err)
+		}
+		for _, resource := range vpcQuotas.Quotas.Resources {
+			if name, ok := vpcQuotaTypes[resource.Type]; ok {
+				quotas[name] = cfg.Quota{Used: resource.Used, Limit: resource.Quota}
+			}
+		}
+		return quotas, nil
+	}
+}
+
+// QuotaRequirements returns amounts of the quotas required by the planned resource
+type QuotaRequirements func(d *schema.ResourceDiff, meta interface{}) (map[string]int, error)
+
+// RequireQuota returns requirements of the fixed amount of single quota
+func RequireQuota(name string, amount int) QuotaRequirements {
+	return func(_ *schema.ResourceDiff, _ interface{}) (map[string]int, error) {
+		return map[string]int{name: amount}, nil
+	}
+}
+
+// RequireVolumeQuota returns requirements of the volume with size set in `sizeArg`.
+// Argument can be a list element expression, e.g. `data_disks.*.size`, then every element is a volume.
+func RequireVolumeQuota(sizeArg string) QuotaRequirements {
+	return func(d *schema.ResourceDiff, _ interface{}) (map[string]int, error) {
+		if !strings.Contains(sizeArg, ".*") {
+			return map[string]int{
+				QuotaVolumes:   1,
+				QuotaGigabytes: d.Get(sizeArg).(int),
+			}, nil
+		}
+
+		reGroups := elementListRegex.FindStringSubmatch(sizeArg)
+		count := d.Get(fmt.Sprintf("%s.#", reGroups[1])).(int)
+		required := map[string]int{QuotaVolumes: count}
+		for i := 0; i < count; i++ {
+			size := d.Get(fmt.Sprintf("%s.%d.%s", reGroups[1], i, reGroups[2])).(int)
+			required[QuotaGigabytes] += size
+		}
+		return required, nil
+	}
+}
+
+// RequireServerQuota returns requirements of the server with flavor ID or name set in `flavorArgs`,
+// the first non-empty argument is used. Cores and RAM are not required if the flavor is unknown.
+func RequireServerQuota(flavorArgs ...string) QuotaRequirements {
+	return func(d *schema.ResourceDiff, meta interface{}) (map[string]int, error) {
+		required := map[string]int{QuotaInstances: 1}
+		var flavorRef string
+		for _, arg := range flavorArgs {
+			if v, ok := d.GetOk(arg); ok {
+				flavorRef = v.(string)
+				break
+			}
+		}
+		if flavorRef == "" {
+			return required, nil
+		}
+		config := meta.(*cfg.Config)
+		flavor, err := computeFlavor(config, config.GetRegion(d), flavorRef)
+		if err != nil {
+			log.Printf("[DEBUG] Can't find flavor %s, cores and RAM quotas are not checked: %s", flavorRef, err)
+			return required, nil
+		}
+		required[QuotaCores] = flavor.VCPUs
+		required[QuotaRAM] = flavor.RAM
+		return required, nil
+	}
+}
+
+// computeFlavor returns the flavor with the given ID or name, the flavor is loaded once per run
+func computeFlavor(config *cfg.Config, region, flavorRef string) (*flavors.Flavor, error) {
+	flavor, err := config.CachedLookup(fmt.Sprintf("compute-flavor/%s/%s", region, flavorRef), func() (interface{}, error) {
+		client, err := config.ComputeV2Client(region)
+		if err != nil {
+			return nil, fmt.Errorf("error creating OpenTelekomCloud ComputeV2 client: %s", err)
+		}
+		flavor, err := flavors.Get(client, flavorRef).Extract()
+		if _, ok := err.(golangsdk.ErrDefault404); !ok {
+			return flavor, err
+		}
+		flavorID, err := flavors.IDFromName(client, flavorRef)
+		if err != nil {
+			return nil, err
+		}
+		return flavors.Get(client, flavorID).Extract()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return flavor.(*flavors.Flavor), nil
+}
+
+// ValidateQuota fails the plan if the quotas left in the region are not enough for the resource being
+// created and for the other resources planned to be created before. Quotas are loaded once per run.
+func ValidateQuota(requirements ...QuotaRequirements) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() != "" {
+			return nil
+		}
+		required := make(map[string]int)
+		for _, requirement := range requirements {
+			amounts, err := requirement(d, meta)
+			if err != nil {
+				return err
+			}
+			for name, amount := range amounts {
+				required[name] += amount
+			}
+		}
+		config := meta.(*cfg.Config)
+		return config.ReserveQuota(config.GetRegion(d), NewQuotaLoader(config), required)
+	}
+}
@@ -1,9 +1,13 @@
 package common
 
 import (
+	"context"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/opentelekomcloud/gophertelekomcloud"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/common/tags"
+
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/cfg"
 )
 
 // TagsSchema returns the schema to use for tags.
@@ -15,6 +19,15 @@ func TagsSchema() *schema.Schema {
 	}
 }
 
+// TagsAllSchema returns the schema to use for `tags_all`: resource tags merged with provider `default_tags`.
+func TagsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+}
+
 func tagsSchemaComputed() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeMap,
@@ -23,26 +36,106 @@ func tagsSchemaComputed() *schema.Schema {
 	}
 }
 
+// MergeDefaultTags returns provider `default_tags` merged with resource tags.
+// Resource tags win on conflicts.
+func MergeDefaultTags(meta interface{}, resourceTags map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	if config, ok := meta.(*cfg.Config); ok {
+		for k, v := range config.DefaultTags {
+			result[k] = v
+		}
+	}
+	for k, v := range resourceTags {
+		result[k] = v
+	}
+	return result
+}
+
+// SetTagsAllDiff is a CustomizeDiffFunc calculating `tags_all` value, so the plan shows the effective tags.
+// It expects the tags fields to be named "tags" and "tags_all"
+func SetTagsAllDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("tags") {
+		return d.SetNewComputed("tags_all")
+	}
+	tagsAll := MergeDefaultTags(meta, d.Get("tags").(map[string]interface{}))
+	if len(tagsAll) == 0 && len(d.Get("tags_all").(map[string]interface{})) == 0 {
+		return nil
+	}
+	return d.SetNew("tags_all", tagsAll)
+}
+
+// FilterIgnoredTags returns tags without the ones matching provider `ignore_tags`
+func FilterIgnoredTags(meta interface{}, tagMap map[string]string) map[string]string {
+	config, ok := meta.(*cfg.Config)
+	if !ok {
+		return tagMap
+	}
+	result := make(map[string]string)
+	for k, v := range tagMap {
+		if !config.IgnoreTags.Ignored(k) {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// SetResourceTags sets tags read from the API to `tags_all`, tags matching provider `ignore_tags` are skipped.
+// Provider `default_tags` are not set to `tags` unless they are set in the resource `tags`.
+func SetResourceTags(d *schema.ResourceData, meta interface{}, tagMap map[string]string) error {
+	tagMap = FilterIgnoredTags(meta, tagMap)
+	if err := d.Set("tags_all", tagMap); err != nil {
+		return err
+	}
+	resourceTags := make(map[string]string)
+	configured := d.Get("tags").(map[string]interface{})
+	var defaultTags map[string]string
+	if config, ok := meta.(*cfg.Config); ok {
+		defaultTags = config.DefaultTags
+	}
+	for k, v := range tagMap {
+		if _, isDefault := defaultTags[k]; isDefault {
+			if _, ok := configured[k]; !ok {
+				continue
+			}
+		}
+		resourceTags[k] = v
+	}
+	return d.Set("tags", resourceTags)
+}
+
 // UpdateResourceTags is a helper to update the tags for a resource.
-// It expects the tags field to be named "tags"
+// It expects the tags fields to be named "tags" and "tags_all".
+// Only the changed tags are updated, so the tags added outside of terraform are kept.
 func UpdateResourceTags(client *golangsdk.ServiceClient, d *schema.ResourceData, resourceType, id string) error {
-	if d.HasChange("tags") {
-		oldMapRaw, newMapRaw := d.GetChange("tags")
+	if d.HasChange("tags_all") {
+		oldMapRaw, newMapRaw := d.GetChange("tags_all")
 		oldMap := oldMapRaw.(map[string]interface{})
 		newMap := newMapRaw.(map[string]interface{})
 
 		// remove old tags
-		if len(oldMap) > 0 {
-			tagList := ExpandResourceTags(oldMap)
+		removed := make(map[string]interface{})
+		for k, v := range oldMap {
+			if _, ok := newMap[k]; !ok {
+				removed[k] = v
+			}
+		}
+		if len(removed) > 0 {
+			tagList := ExpandResourceTags(removed)
 			err := tags.Delete(client, resourceType, id, tagList).ExtractErr()
 			if err != nil {
 				return err
 			}
 		}
 
-		// set new tags
-		if len(newMap) > 0 {
-			tagList := ExpandResourceTags(newMap)
+		// set new and changed tags
+		changed := make(map[string]interface{})
+		for k, v := range newMap {
+			if oldValue, ok := oldMap[k]; !ok || oldValue != v {
+				changed[k] = v
+			}
+		}
+		if len(changed) > 0 {
+			tagList := ExpandResourceTags(changed)
 			err := tags.Create(client, resourceType, id, tagList).ExtractErr()
 			if err != nil {
 				return err
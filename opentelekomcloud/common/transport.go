@@ -7,9 +7,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
-	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -158,16 +156,3 @@ func convertToStr(v interface{}) (string, error) {
 
 	return "", fmt.Errorf("can't convert to string")
 }
-
-func WaitToFinish(target, pending []string, timeout, interval time.Duration, f resource.StateRefreshFunc) (interface{}, error) {
-	stateConf := &resource.StateChangeConf{
-		Target:     target,
-		Pending:    pending,
-		Refresh:    f,
-		Timeout:    timeout,
-		Delay:      5 * time.Second,
-		MinTimeout: interval,
-	}
-
-	return stateConf.WaitForState()
-}
@@ -0,0 +1,238 @@
+// Package waiter provides waiting for the long-running operations of the resources to finish.
+package waiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+	"time"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+)
+
+// Deleted is the state reported for the resource which is not found.
+// Use it as a target to wait for the resource deletion.
+const Deleted = "DELETED"
+
+const (
+	defaultMinInterval    = 2 * time.Second
+	defaultMaxInterval    = 30 * time.Second
+	defaultNotFoundChecks = 3
+
+	// jitterFactor is the max relative deviation of the randomized polling interval
+	jitterFactor = 0.2
+)
+
+// RefreshFunc returns the current resource and its state.
+// Resource which is not found must be reported either with `nil` result or with 404 error.
+type RefreshFunc func() (result interface{}, state string, err error)
+
+// Config describes the waiting for the resource to reach one of the target states
+type Config struct {
+	// Description of the waited resource used in logs and errors, e.g. `CCE cluster <id>`
+	Description string
+
+	// Pending states, waiting continues while resource is in one of them.
+	// If empty, any state which is neither target nor error one is treated as pending.
+	Pending []string
+	// Target states, waiting succeeds as soon as resource reaches one of them
+	Target []string
+	// Error states, waiting fails as soon as resource reaches one of them
+	Error []string
+
+	Refresh RefreshFunc
+
+	// Timeout of the whole waiting, zero means waiting until the context is done
+	Timeout time.Duration
+	// Delay before the first refresh
+	Delay time.Duration
+	// MinInterval is the initial interval between refreshes, it grows exponentially up to MaxInterval
+	MinInterval time.Duration
+	MaxInterval time.Duration
+
+	// NotFoundChecks is the number of the consecutive refreshes not finding the resource
+	// tolerated when not waiting for the deletion, e.g. because of eventual consistency
+	NotFoundChecks int
+}
+
+// UnexpectedStateError is returned when the resource reaches neither pending nor target state
+type UnexpectedStateError struct {
+	Description string
+	State       string
+	Expected    []string
+}
+
+func (e *UnexpectedStateError) Error() string {
+	return fmt.Sprintf("unexpected state of %s: %q, wanted one of: %s",
+		e.Description, e.State, strings.Join(e.Expected, ", "))
+}
+
+// FailedStateError is returned when the resource reaches one of the error states
+type FailedStateError struct {
+	Description string
+	State       string
+}
+
+func (e *FailedStateError) Error() string {
+	return fmt.Sprintf("%s reached error state: %s", e.Description, e.State)
+}
+
+// NotFoundError is returned when the resource is not found while not waiting for its deletion
+type NotFoundError struct {
+	Description string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s not found", e.Description)
+}
+
+// TimeoutError is returned when the resource doesn't reach target state in time
+type TimeoutError struct {
+	Description string
+	LastState   string
+	Target      []string
+	Timeout     time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("timeout while waiting for %s to become %s (last state: %q, timeout: %s)",
+		e.Description, strings.Join(e.Target, ", "), e.LastState, e.Timeout)
+}
+
+// IsNotFound checks if the error is API 404 error
+func IsNotFound(err error) bool {
+	var notFound golangsdk.ErrDefault404
+	return errors.As(err, &notFound)
+}
+
+// Wait refreshes the resource until it reaches one of the target states and returns its last result.
+// Resource which is not found is treated as the one in `Deleted` state.
+func (c *Config) Wait(ctx context.Context) (interface{}, error) {
+	description := c.Description
+	if description == "" {
+		description = "resource"
+	}
+	minInterval := c.MinInterval
+	if minInterval <= 0 {
+		minInterval = defaultMinInterval
+	}
+	maxInterval := c.MaxInterval
+	if maxInterval < minInterval {
+		maxInterval = defaultMaxInterval
+		if maxInterval < minInterval {
+			maxInterval = minInterval
+		}
+	}
+	notFoundChecks := c.NotFoundChecks
+	if notFoundChecks <= 0 {
+		notFoundChecks = defaultNotFoundChecks
+	}
+
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+
+	log.Printf("[DEBUG] Waiting for %s to become %s", description, strings.Join(c.Target, ", "))
+
+	start := time.Now()
+	lastState := ""
+	notFoundCount := 0
+	interval := minInterval
+	next := c.Delay
+
+	for {
+		if err := sleep(ctx, next); err != nil {
+			return nil, c.contextError(ctx, description, lastState, err)
+		}
+
+		result, state, err := c.Refresh()
+		if err != nil && !IsNotFound(err) {
+			return result, fmt.Errorf("error refreshing %s: %w", description, err)
+		}
+		if err != nil || result == nil {
+			state = Deleted
+		}
+
+		if state != lastState {
+			log.Printf("[DEBUG] %s: state changed from %q to %q after %s",
+				description, lastState, state, time.Since(start).Round(time.Second))
+			lastState = state
+			interval = minInterval
+		}
+
+		switch {
+		case contains(c.Target, state):
+			return result, nil
+		case state == Deleted:
+			notFoundCount++
+			if notFoundCount > notFoundChecks {
+				return nil, &NotFoundError{Description: description}
+			}
+		case contains(c.Error, state):
+			return result, &FailedStateError{Description: description, State: state}
+		case len(c.Pending) != 0 && !contains(c.Pending, state):
+			expected := append(append([]string{}, c.Pending...), c.Target...)
+			return result, &UnexpectedStateError{Description: description, State: state, Expected: expected}
+		default:
+			notFoundCount = 0
+		}
+
+		next = jitter(interval)
+		log.Printf("[TRACE] %s is %q, next check in %s", description, state, next.Round(time.Millisecond))
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+func (c *Config) contextError(ctx context.Context, description, lastState string, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &TimeoutError{
+			Description: description,
+			LastState:   lastState,
+			Target:      c.Target,
+			Timeout:     c.Timeout,
+		}
+	}
+	return fmt.Errorf("waiting for %s interrupted: %w", description, ctx.Err())
+}
+
+// sleep waits for the given duration or until the context is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// jitter randomizes the interval so the resources waited in parallel don't poll API at the same moment
+func jitter(interval time.Duration) time.Duration {
+	deviation := int64(float64(interval) * jitterFactor)
+	if deviation <= 0 {
+		return interval
+	}
+	return interval - time.Duration(deviation) + time.Duration(rand.Int63n(2*deviation+1))
+}
+
+func contains(states []string, state string) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package waiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	th "github.com/opentelekomcloud/gophertelekomcloud/testhelper"
+)
+
+// sequence returns refresh func reporting the given states one by one, the last one is repeated
+func sequence(states ...string) (RefreshFunc, *int) {
+	calls := 0
+	return func() (interface{}, string, error) {
+		state := states[len(states)-1]
+		if calls < len(states) {
+			state = states[calls]
+		}
+		calls++
+		if state == Deleted {
+			return nil, "", golangsdk.ErrDefault404{}
+		}
+		return state, state, nil
+	}, &calls
+}
+
+func testConfig(refresh RefreshFunc) *Config {
+	return &Config{
+		Description: "test resource",
+		Pending:     []string{"CREATING", "UPDATING"},
+		Target:      []string{"AVAILABLE"},
+		Error:       []string{"ERROR"},
+		Refresh:     refresh,
+		Timeout:     time.Second,
+		MinInterval: time.Millisecond,
+		MaxInterval: 4 * time.Millisecond,
+	}
+}
+
+func TestWaitTarget(t *testing.T) {
+	refresh, calls := sequence("CREATING", "UPDATING", "AVAILABLE")
+	result, err := testConfig(refresh).Wait(context.Background())
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "AVAILABLE", result)
+	th.AssertEquals(t, 3, *calls)
+}
+
+func TestWaitErrorState(t *testing.T) {
+	refresh, _ := sequence("CREATING", "ERROR")
+	_, err := testConfig(refresh).Wait(context.Background())
+
+	var stateErr *FailedStateError
+	th.AssertEquals(t, true, errors.As(err, &stateErr))
+	th.AssertEquals(t, "ERROR", stateErr.State)
+}
+
+func TestWaitUnexpectedState(t *testing.T) {
+	refresh, _ := sequence("CREATING", "SHUTOFF")
+	_, err := testConfig(refresh).Wait(context.Background())
+
+	var stateErr *UnexpectedStateError
+	th.AssertEquals(t, true, errors.As(err, &stateErr))
+	th.AssertEquals(t, "SHUTOFF", stateErr.State)
+}
+
+func TestWaitAnyPending(t *testing.T) {
+	refresh, _ := sequence("CREATING", "SHUTOFF", "AVAILABLE")
+	conf := testConfig(refresh)
+	conf.Pending = nil
+	_, err := conf.Wait(context.Background())
+	th.AssertNoErr(t, err)
+}
+
+func TestWaitDeleted(t *testing.T) {
+	refresh, _ := sequence("DELETING", Deleted)
+	conf := testConfig(refresh)
+	conf.Pending = []string{"DELETING"}
+	conf.Target = []string{Deleted}
+	result, err := conf.Wait(context.Background())
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, nil, result)
+}
+
+func TestWaitNotFound(t *testing.T) {
+	refresh, calls := sequence(Deleted)
+	conf := testConfig(refresh)
+	conf.NotFoundChecks = 2
+	_, err := conf.Wait(context.Background())
+
+	var notFoundErr *NotFoundError
+	th.AssertEquals(t, true, errors.As(err, &notFoundErr))
+	th.AssertEquals(t, 3, *calls)
+}
+
+func TestWaitNotFoundTolerated(t *testing.T) {
+	refresh, _ := sequence(Deleted, Deleted, "CREATING", "AVAILABLE")
+	conf := testConfig(refresh)
+	conf.NotFoundChecks = 2
+	_, err := conf.Wait(context.Background())
+	th.AssertNoErr(t, err)
+}
+
+func TestWaitRefreshError(t *testing.T) {
+	refreshErr := fmt.Errorf("boom")
+	conf := testConfig(func() (interface{}, string, error) {
+		return nil, "", refreshErr
+	})
+	_, err := conf.Wait(context.Background())
+	th.AssertEquals(t, true, errors.Is(err, refreshErr))
+}
+
+func TestWaitTimeout(t *testing.T) {
+	refresh, _ := sequence("CREATING")
+	conf := testConfig(refresh)
+	conf.Timeout = 20 * time.Millisecond
+	_, err := conf.Wait(context.Background())
+
+	var timeoutErr *TimeoutError
+	th.AssertEquals(t, true, errors.As(err, &timeoutErr))
+	th.AssertEquals(t, "CREATING", timeoutErr.LastState)
+}
+
+func TestWaitCancel(t *testing.T) {
+	refresh, _ := sequence("CREATING")
+	conf := testConfig(refresh)
+	conf.Timeout = 0
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	_, err := conf.Wait(ctx)
+	th.AssertEquals(t, true, errors.Is(err, context.Canceled))
+}
+
+func TestJitter(t *testing.T) {
+	interval := 10 * time.Second
+	for i := 0; i < 100; i++ {
+		d := jitter(interval)
+		if d < 8*time.Second || d > 12*time.Second {
+			t.Fatalf("jittered interval %s is out of range", d)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common"
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/cfg"
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/services/antiddos"
@@ -132,6 +133,12 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("OS_SECURITY_TOKEN", ""),
 				Description: common.Descriptions["security_token"],
 			},
+			"credential_process": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_CREDENTIAL_PROCESS", ""),
+				Description: common.Descriptions["credential_process"],
+			},
 			"passcode": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -195,22 +202,55 @@ func Provider() *schema.Provider {
 				Description: common.Descriptions["swauth"],
 			},
 			"agency_name": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("OS_AGENCY_NAME", ""),
-				Description: common.Descriptions["agency_name"],
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("OS_AGENCY_NAME", ""),
+				Description:   common.Descriptions["agency_name"],
+				ConflictsWith: []string{"assume_role"},
 			},
 			"agency_domain_name": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("OS_AGENCY_DOMAIN_NAME", ""),
-				Description: common.Descriptions["agency_domain_name"],
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("OS_AGENCY_DOMAIN_NAME", ""),
+				Description:   common.Descriptions["agency_domain_name"],
+				ConflictsWith: []string{"assume_role"},
 			},
 			"delegated_project": {
-				Type:        schema.TypeString,
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("OS_DELEGATED_PROJECT", ""),
+				Description:   common.Descriptions["delegated_project"],
+				ConflictsWith: []string{"assume_role"},
+			},
+			"assume_role": {
+				Type:        schema.TypeList,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("OS_DELEGATED_PROJECT", ""),
-				Description: common.Descriptions["delegated_project"],
+				Description: common.Descriptions["assume_role"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"agency_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"domain_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"delegated_project": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"duration": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(900, 86400),
+						},
+						"session_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
 			},
 			"cloud": {
 				Type:        schema.TypeString,
@@ -224,11 +264,71 @@ func Provider() *schema.Provider {
 				Default:     1,
 				Description: common.Descriptions["max_retries"],
 			},
+			"default_tags": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: common.ValidateTags,
+				Description:  common.Descriptions["default_tags"],
+			},
+			"ignore_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: common.Descriptions["ignore_tags"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"keys": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"key_prefixes": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"endpoints": endpointsSchema(),
+			"sensitive_keys": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: common.Descriptions["sensitive_keys"],
+			},
+			"trace_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_TRACE_FILE", ""),
+				Description: common.Descriptions["trace_file"],
+			},
+			"rate_limit": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: common.Descriptions["rate_limit"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"requests_per_second": {
+							Type:         schema.TypeFloat,
+							Required:     true,
+							ValidateFunc: validation.FloatAtLeast(0.01),
+						},
+					},
+				},
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
 			"opentelekomcloud_antiddos_v1":                   antiddos.DataSourceAntiDdosV1(),
+			"opentelekomcloud_cce_addon_template_v3":         cce.DataSourceCCEAddonTemplateV3(),
 			"opentelekomcloud_cce_cluster_v3":                cce.DataSourceCCEClusterV3(),
+			"opentelekomcloud_cce_cluster_kubeconfig_v3":     cce.DataSourceCCEClusterKubeConfigV3(),
 			"opentelekomcloud_cce_node_ids_v3":               cce.DataSourceCceNodeIdsV3(),
 			"opentelekomcloud_cce_node_v3":                   cce.DataSourceCceNodesV3(),
 			"opentelekomcloud_compute_availability_zones_v2": ecs.DataSourceComputeAvailabilityZonesV2(),
@@ -264,6 +364,7 @@ func Provider() *schema.Provider {
 			"opentelekomcloud_networking_port_v2":            vpc.DataSourceNetworkingPortV2(),
 			"opentelekomcloud_networking_secgroup_v2":        vpc.DataSourceNetworkingSecGroupV2(),
 			"opentelekomcloud_obs_bucket_object":             obs.DataSourceObsBucketObject(),
+			"opentelekomcloud_quotas_v1":                     vpc.DataSourceQuotasV1(),
 			"opentelekomcloud_rds_flavors_v1":                rds.DataSourceRdsFlavorV1(),
 			"opentelekomcloud_rds_flavors_v3":                rds.DataSourceRdsFlavorV3(),
 			"opentelekomcloud_rds_versions_v3":               rds.DataSourceRdsVersionsV3(),
@@ -435,6 +536,13 @@ func Provider() *schema.Provider {
 		return providerConfigure(ctx, d, provider)
 	}
 
+	for name, resource := range provider.DataSourcesMap {
+		traceResource(name, resource)
+	}
+	for name, resource := range provider.ResourcesMap {
+		traceResource(name, resource)
+	}
+
 	return provider
 }
 
@@ -464,8 +572,17 @@ func providerConfigure(_ context.Context, d *schema.ResourceData, p *schema.Prov
 		AgencyName:       d.Get("agency_name").(string),
 		AgencyDomainName: d.Get("agency_domain_name").(string),
 		DelegatedProject: d.Get("delegated_project").(string),
+		AssumeRoles:      expandAssumeRoles(d),
 		MaxRetries:       d.Get("max_retries").(int),
+		RateLimits:       expandRateLimits(d),
+		DefaultTags:      expandDefaultTags(d),
+		IgnoreTags:       expandIgnoreTags(d),
+		Endpoints:        expandEndpoints(d),
+		TraceFile:        d.Get("trace_file").(string),
+		SensitiveKeys:    common.ExpandToStringSlice(d.Get("sensitive_keys").([]interface{})),
 		UserAgent:        p.UserAgent("terraform-provider-opentelekomcloud", version.ProviderVersion),
+
+		CredentialProcess: d.Get("credential_process").(string),
 	}
 
 	if err := config.LoadAndValidate(); err != nil {
@@ -474,3 +591,121 @@ func providerConfigure(_ context.Context, d *schema.ResourceData, p *schema.Prov
 
 	return &config, nil
 }
+
+func expandAssumeRoles(d *schema.ResourceData) []cfg.AssumeRole {
+	rawRoles := d.Get("assume_role").([]interface{})
+	roles := make([]cfg.AssumeRole, len(rawRoles))
+	for i, raw := range rawRoles {
+		role := raw.(map[string]interface{})
+		roles[i] = cfg.AssumeRole{
+			AgencyName:       role["agency_name"].(string),
+			DomainName:       role["domain_name"].(string),
+			DelegatedProject: role["delegated_project"].(string),
+			Duration:         role["duration"].(int),
+			SessionName:      role["session_name"].(string),
+		}
+	}
+	return roles
+}
+
+func expandRateLimits(d *schema.ResourceData) []cfg.RateLimit {
+	rawLimits := d.Get("rate_limit").([]interface{})
+	limits := make([]cfg.RateLimit, len(rawLimits))
+	for i, raw := range rawLimits {
+		limit := raw.(map[string]interface{})
+		limits[i] = cfg.RateLimit{
+			Service:           limit["service"].(string),
+			RequestsPerSecond: limit["requests_per_second"].(float64),
+		}
+	}
+	return limits
+}
+
+func expandDefaultTags(d *schema.ResourceData) map[string]string {
+	defaultTags := make(map[string]string)
+	for k, v := range d.Get("default_tags").(map[string]interface{}) {
+		defaultTags[k] = v.(string)
+	}
+	return defaultTags
+}
+
+func expandIgnoreTags(d *schema.ResourceData) cfg.IgnoreTags {
+	var ignoreTags cfg.IgnoreTags
+	rawIgnoreTags := d.Get("ignore_tags").([]interface{})
+	if len(rawIgnoreTags) == 0 || rawIgnoreTags[0] == nil {
+		return ignoreTags
+	}
+	ignore := rawIgnoreTags[0].(map[string]interface{})
+	ignoreTags.Keys = common.ExpandToStringSlice(ignore["keys"].(*schema.Set).List())
+	ignoreTags.KeyPrefixes = common.ExpandToStringSlice(ignore["key_prefixes"].(*schema.Set).List())
+	return ignoreTags
+}
+
+func endpointsSchema() *schema.Schema {
+	endpoints := make(map[string]*schema.Schema)
+	for _, service := range cfg.EndpointServices {
+		endpoints[service] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+		}
+	}
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: common.Descriptions["endpoints"],
+		Elem: &schema.Resource{
+			Schema: endpoints,
+		},
+	}
+}
+
+func expandEndpoints(d *schema.ResourceData) map[string]string {
+	endpoints := make(map[string]string)
+	rawEndpoints := d.Get("endpoints").([]interface{})
+	if len(rawEndpoints) == 0 || rawEndpoints[0] == nil {
+		return endpoints
+	}
+	for service, endpoint := range rawEndpoints[0].(map[string]interface{}) {
+		if endpoint.(string) != "" {
+			endpoints[service] = endpoint.(string)
+		}
+	}
+	return endpoints
+}
+
+// traceResource makes the resource operations use the config scoped to the resource,
+// so the requests sent by the resource are written to the trace file with its type and ID
+func traceResource(name string, resource *schema.Resource) {
+	scoped := func(meta interface{}, id string) interface{} {
+		if config, ok := meta.(*cfg.Config); ok {
+			return config.ForResource(name, id)
+		}
+		return meta
+	}
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return f(ctx, d, scoped(meta, d.Id()))
+		}
+	}
+	resource.CreateContext = wrap(resource.CreateContext)
+	resource.ReadContext = wrap(resource.ReadContext)
+	resource.UpdateContext = wrap(resource.UpdateContext)
+	resource.DeleteContext = wrap(resource.DeleteContext)
+
+	if customizeDiff := resource.CustomizeDiff; customizeDiff != nil {
+		resource.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			return customizeDiff(ctx, d, scoped(meta, d.Id()))
+		}
+	}
+	if resource.Importer != nil && resource.Importer.StateContext != nil {
+		importState := resource.Importer.StateContext
+		resource.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			return importState(ctx, d, scoped(meta, d.Id()))
+		}
+	}
+}
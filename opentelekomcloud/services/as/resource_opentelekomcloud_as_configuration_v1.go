@@ -24,7 +24,14 @@ func ResourceASConfiguration() *schema.Resource {
 		ReadContext:   resourceASConfigurationRead,
 		DeleteContext: resourceASConfigurationDelete,
 
-		CustomizeDiff: validateDiskSize,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: common.MultipleCustomizeDiffs(
+			validateDiskSize,
+			common.ValidateFlavor("", "instance_config.0.flavor"),
+		),
 
 		Schema: map[string]*schema.Schema{
 			"region": {
@@ -365,6 +372,43 @@ func resourceASConfigurationRead(_ context.Context, d *schema.ResourceData, meta
 	instanceConfigInfo["image"] = asConfig.InstanceConfig.ImageRef
 	instanceConfigInfo["key_name"] = asConfig.InstanceConfig.SSHKey
 	instanceConfigInfo["user_data"] = common.InstallScriptHashSum(asConfig.InstanceConfig.UserData)
+	instanceConfigInfo["metadata"] = asConfig.InstanceConfig.Metadata
+
+	disks := make([]interface{}, len(asConfig.InstanceConfig.Disk))
+	for i, disk := range asConfig.InstanceConfig.Disk {
+		kmsID, _ := disk.Metadata["__system__cmkid"].(string)
+		disks[i] = map[string]interface{}{
+			"size":        disk.Size,
+			"volume_type": disk.VolumeType,
+			"disk_type":   disk.DiskType,
+			"kms_id":      kmsID,
+		}
+	}
+	instanceConfigInfo["disk"] = disks
+
+	personality := make([]interface{}, len(asConfig.InstanceConfig.Personality))
+	for i, file := range asConfig.InstanceConfig.Personality {
+		personality[i] = map[string]interface{}{
+			"path":    file.Path,
+			"content": file.Content,
+		}
+	}
+	instanceConfigInfo["personality"] = personality
+
+	publicIPs := make([]interface{}, 0, 1)
+	if eip := asConfig.InstanceConfig.PublicIp.Eip; eip.Type != "" {
+		publicIPs = append(publicIPs, map[string]interface{}{
+			"eip": []interface{}{map[string]interface{}{
+				"ip_type": eip.Type,
+				"bandwidth": []interface{}{map[string]interface{}{
+					"size":          eip.Bandwidth.Size,
+					"share_type":    eip.Bandwidth.ShareType,
+					"charging_mode": eip.Bandwidth.ChargingMode,
+				}},
+			}},
+		})
+	}
+	instanceConfigInfo["public_ip"] = publicIPs
 
 	var secGrpIDs []string
 	for _, sg := range asConfig.InstanceConfig.SecurityGroups {
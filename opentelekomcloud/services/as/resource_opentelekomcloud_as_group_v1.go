@@ -29,11 +29,17 @@ func ResourceASGroup() *schema.Resource {
 		UpdateContext: resourceASGroupUpdate,
 		DeleteContext: resourceASGroupDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: common.SetTagsAllDiff,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -106,6 +112,7 @@ func ResourceASGroup() *schema.Resource {
 			"available_zones": {
 				Type:     schema.TypeList,
 				Optional: true,
+				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 			"networks": {
@@ -124,6 +131,7 @@ func ResourceASGroup() *schema.Resource {
 			"security_groups": {
 				Type:     schema.TypeList,
 				Optional: true,
+				Computed: true,
 				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -200,7 +208,8 @@ func ResourceASGroup() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsAllSchema(),
 		},
 	}
 }
@@ -417,7 +426,7 @@ func resourceASGroupCreate(ctx context.Context, d *schema.ResourceData, meta int
 	}
 
 	// set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := d.Get("tags_all").(map[string]interface{})
 	if len(tagRaw) > 0 {
 		tagList := common.ExpandResourceTags(tagRaw)
 		if err := tags.Create(client, "scaling_group_tag", asGroupID, tagList).ExtractErr(); err != nil {
@@ -463,8 +472,20 @@ func resourceASGroupRead(_ context.Context, d *schema.ResourceData, meta interfa
 		d.Set("instance_terminate_policy", asGroup.InstanceTerminatePolicy),
 		d.Set("scaling_configuration_id", asGroup.ConfigurationID),
 		d.Set("delete_publicip", asGroup.DeletePublicIP),
+		d.Set("vpc_id", asGroup.VpcID),
+		d.Set("available_zones", asGroup.AvailableZones),
 		d.Set("region", config.GetRegion(d)),
 	)
+	networks := make([]map[string]interface{}, len(asGroup.Networks))
+	for i, network := range asGroup.Networks {
+		networks[i] = map[string]interface{}{"id": network.ID}
+	}
+	mErr = multierror.Append(mErr, d.Set("networks", networks))
+	securityGroups := make([]map[string]interface{}, len(asGroup.SecurityGroups))
+	for i, group := range asGroup.SecurityGroups {
+		securityGroups[i] = map[string]interface{}{"id": group.ID}
+	}
+	mErr = multierror.Append(mErr, d.Set("security_groups", securityGroups))
 	if len(asGroup.Notifications) >= 1 {
 		if err := d.Set("notifications", asGroup.Notifications); err != nil {
 			return diag.FromErr(err)
@@ -503,7 +524,7 @@ func resourceASGroupRead(_ context.Context, d *schema.ResourceData, meta interfa
 		return fmterr.Errorf("error fetching OpenTelekomCloud AutoScaling Group tags: %s", err)
 	}
 	tagMap := common.TagsToMap(resourceTags)
-	if err := d.Set("tags", tagMap); err != nil {
+	if err := common.SetResourceTags(d, meta, tagMap); err != nil {
 		return fmterr.Errorf("error saving tags for OpenTelekomCloud AutoScaling Group: %s", err)
 	}
 
@@ -561,7 +582,7 @@ func resourceASGroupUpdate(ctx context.Context, d *schema.ResourceData, meta int
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if d.HasChange("tags_all") {
 		if err := common.UpdateResourceTags(client, d, "scaling_group_tag", d.Id()); err != nil {
 			return fmterr.Errorf("error updating tags of AutoScaling Group %s: %s", d.Id(), err)
 		}
@@ -24,6 +24,10 @@ func ResourceASPolicy() *schema.Resource {
 		UpdateContext: resourceASPolicyUpdate,
 		DeleteContext: resourceASPolicyDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -240,6 +244,8 @@ func resourceASPolicyRead(_ context.Context, d *schema.ResourceData, meta interf
 
 	log.Printf("[DEBUG] Retrieved ASPolicy %q: %+v", d.Id(), asPolicy)
 	d.Set("scaling_policy_name", asPolicy.Name)
+	// `ID` of the policy is the ID of the scaling group
+	d.Set("scaling_group_id", asPolicy.ID)
 	d.Set("scaling_policy_type", asPolicy.Type)
 	d.Set("alarm_id", asPolicy.AlarmID)
 	d.Set("cool_down_time", asPolicy.CoolDownTime)
@@ -22,6 +22,10 @@ func ResourceASPolicyV2() *schema.Resource {
 		UpdateContext: resourceASPolicyV2Update,
 		DeleteContext: resourceASPolicyV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -34,6 +35,10 @@ func ResourceComputeBMSInstanceV2() *schema.Resource {
 		UpdateContext: resourceComputeBMSInstanceV2Update,
 		DeleteContext: resourceComputeBMSInstanceV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
 			Update: schema.DefaultTimeout(30 * time.Minute),
@@ -488,8 +493,34 @@ func resourceComputeBMSInstanceV2Read(_ context.Context, d *schema.ResourceData,
 	d.Set("host_id", server.HostID)
 	d.Set("kernel_id", server.KernelId)
 	d.Set("user_id", server.UserID)
+	d.Set("key_pair", server.KeyName)
+	d.Set("config_drive", server.ConfigDrive == "True")
 	d.Set("region", config.GetRegion(d))
 
+	secGroupNames := make([]string, len(server.SecurityGroups))
+	for i, group := range server.SecurityGroups {
+		secGroupNames[i] = group.Name
+	}
+	if err := d.Set("security_groups", secGroupNames); err != nil {
+		return fmterr.Errorf("error saving security_groups of BMS server %s: %s", d.Id(), err)
+	}
+
+	serverTags, err := ecstags.Get(computeClient, d.Id()).Extract()
+	if err != nil {
+		return fmterr.Errorf("error fetching OpenTelekomCloud instance tags: %s", err)
+	}
+	tagMap := make(map[string]string, len(serverTags.Tags))
+	for _, tag := range serverTags.Tags {
+		// skip system tags, e.g. `__type_baremetal`
+		if strings.HasPrefix(tag.Key, "__") {
+			continue
+		}
+		tagMap[tag.Key] = tag.Value
+	}
+	if err := d.Set("tags", tagMap); err != nil {
+		return fmterr.Errorf("error saving tags of BMS server %s: %s", d.Id(), err)
+	}
+
 	return nil
 }
 
@@ -23,6 +23,10 @@ func ResourceCBRPolicyV3() *schema.Resource {
 		UpdateContext: resourceCBRPolicyV3Update,
 		DeleteContext: resourceCBRPolicyV3Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cbr/v3/policies"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cbr/v3/vaults"
 
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common"
@@ -24,7 +25,11 @@ func ResourceCBRVaultV3() *schema.Resource {
 		UpdateContext: resourceCBRVaultV3Update,
 		DeleteContext: resourceCBRVaultV3Delete,
 
-		CustomizeDiff: common.MultipleCustomizeDiffs(cbrVaultRequiredFields),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: common.MultipleCustomizeDiffs(common.SetTagsAllDiff, cbrVaultRequiredFields),
 
 		Schema: map[string]*schema.Schema{
 			"description": {
@@ -208,7 +213,8 @@ func ResourceCBRVaultV3() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsAllSchema(),
 			"enterprise_project_id": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -295,13 +301,30 @@ func resourceCBRVaultV3Read(_ context.Context, d *schema.ResourceData, meta inte
 		}
 	}
 
+	pages, err := policies.List(client, policies.ListOpts{
+		OperationType: "backup",
+		VaultID:       d.Id(),
+	}).AllPages()
+	if err != nil {
+		return fmterr.Errorf("error listing vault policies: %s", err)
+	}
+	vaultPolicies, err := policies.ExtractPolicies(pages)
+	if err != nil {
+		return fmterr.Errorf("error extracting vault policies: %s", err)
+	}
+	var backupPolicyID interface{}
+	if len(vaultPolicies) != 0 {
+		backupPolicyID = vaultPolicies[0].ID
+	}
+
 	mErr := multierror.Append(
 		d.Set("description", vault.Description),
+		d.Set("backup_policy_id", backupPolicyID),
 		d.Set("name", vault.Name),
 		d.Set("project_id", vault.ProjectID),
 		d.Set("provider_id", vault.ProviderID),
 		d.Set("resource", resourceList),
-		d.Set("tags", tagsMap),
+		common.SetResourceTags(d, meta, tagsMap),
 		d.Set("enterprise_project_id", vault.EnterpriseProjectID),
 		d.Set("auto_bind", vault.AutoBind),
 		d.Set("auto_expand", vault.AutoExpand),
@@ -465,7 +488,7 @@ func cbrVaultBindRules(d *schema.ResourceData) (rules *vaults.VaultBindRules) {
 }
 
 func cbrVaultTags(d *schema.ResourceData) []vaults.Tag {
-	tags := d.Get("tags").(map[string]interface{})
+	tags := d.Get("tags_all").(map[string]interface{})
 	var tagSlice []vaults.Tag
 	for k, v := range tags {
 		tagSlice = append(tagSlice, vaults.Tag{Key: k, Value: v.(string)})
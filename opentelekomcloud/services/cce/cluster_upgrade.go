@@ -0,0 +1,312 @@
+package cce
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/addons"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/clusters"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/nodes"
+
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/cfg"
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/waiter"
+)
+
+const (
+	upgradeTaskSuccess = "Success"
+	upgradeTaskFailed  = "Failed"
+)
+
+// clusterUpgradeTask is the pre-upgrade check or the upgrade task of the cluster
+type clusterUpgradeTask struct {
+	Metadata struct {
+		ID string `json:"uid"`
+	} `json:"metadata"`
+	Status struct {
+		Phase   string `json:"phase"`
+		Message string `json:"message"`
+	} `json:"status"`
+}
+
+type clusterUpgradeSpec struct {
+	ClusterUpgradeAction struct {
+		TargetVersion string `json:"targetVersion"`
+	} `json:"clusterUpgradeAction"`
+}
+
+// upgradeCCECluster runs the pre-upgrade check and upgrades the cluster to the configured version.
+// It waits for both masters and nodes to be upgraded.
+func upgradeCCECluster(ctx context.Context, d *schema.ResourceData, client *golangsdk.ServiceClient) error {
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	targetVersion := d.Get("cluster_version").(string)
+
+	var spec clusterUpgradeSpec
+	spec.ClusterUpgradeAction.TargetVersion = targetVersion
+
+	log.Printf("[DEBUG] Running pre-upgrade check of CCE cluster %s to %s", d.Id(), targetVersion)
+	preCheck := map[string]interface{}{
+		"apiVersion": "v3",
+		"kind":       "PreCheckTask",
+		"spec":       spec,
+	}
+	if err := runCCEClusterUpgradeTask(ctx, client, d.Id(), "precheck", preCheck, timeout); err != nil {
+		return fmt.Errorf("pre-upgrade check failed: %w", err)
+	}
+
+	// nodes already failed are not upgraded, so they are not waited for
+	clusterNodes, err := nodes.List(client, d.Id(), nodes.ListOpts{})
+	if err != nil {
+		return fmt.Errorf("error listing cluster nodes: %w", err)
+	}
+	failedNodes := make(map[string]bool)
+	for _, node := range clusterNodes {
+		if node.Status.Phase == "Error" {
+			log.Printf("[WARN] Node %s of CCE cluster %s is in `Error` state, its upgrade is not waited for", node.Metadata.Id, d.Id())
+			failedNodes[node.Metadata.Id] = true
+		}
+	}
+
+	log.Printf("[DEBUG] Upgrading CCE cluster %s to %s", d.Id(), targetVersion)
+	upgrade := map[string]interface{}{
+		"metadata": map[string]string{
+			"apiVersion": "v3",
+			"kind":       "UpgradeTask",
+		},
+		"spec": spec,
+	}
+	if err := runCCEClusterUpgradeTask(ctx, client, d.Id(), "upgrade", upgrade, timeout); err != nil {
+		return fmt.Errorf("upgrade failed: %w", err)
+	}
+
+	if err := waitForCCEClusterAvailable(ctx, client, d.Id(), timeout); err != nil {
+		return fmt.Errorf("error waiting for cluster masters to be upgraded: %w", err)
+	}
+	stateConf := &waiter.Config{
+		Description: fmt.Sprintf("nodes of CCE cluster %s", d.Id()),
+		Target:      []string{"Active"},
+		Error:       []string{"Error"},
+		Refresh:     cceClusterNodesRefreshFunc(client, d.Id(), failedNodes),
+		Timeout:     timeout,
+		Delay:       15 * time.Second,
+		MinInterval: 10 * time.Second,
+		MaxInterval: time.Minute,
+	}
+	if _, err := stateConf.Wait(ctx); err != nil {
+		return fmt.Errorf("error waiting for cluster nodes to be upgraded: %w", err)
+	}
+	return nil
+}
+
+// runCCEClusterUpgradeTask starts the upgrade operation task and waits for it to succeed
+func runCCEClusterUpgradeTask(ctx context.Context, client *golangsdk.ServiceClient, clusterID, operation string, body interface{}, timeout time.Duration) error {
+	task := new(clusterUpgradeTask)
+	_, err := client.Post(client.ServiceURL("clusters", clusterID, "operation", operation), body, task, &golangsdk.RequestOpts{
+		OkCodes:     []int{200, 201},
+		MoreHeaders: clusters.RequestOpts.MoreHeaders,
+	})
+	if err != nil {
+		return logHttpError(err)
+	}
+
+	stateConf := &waiter.Config{
+		Description: fmt.Sprintf("CCE cluster %s %s task %s", clusterID, operation, task.Metadata.ID),
+		Target:      []string{upgradeTaskSuccess},
+		Error:       []string{upgradeTaskFailed},
+		Refresh:     cceClusterUpgradeTaskRefreshFunc(client, clusterID, operation, task.Metadata.ID),
+		Timeout:     timeout,
+		Delay:       10 * time.Second,
+		MinInterval: 10 * time.Second,
+		MaxInterval: time.Minute,
+	}
+	result, err := stateConf.Wait(ctx)
+	var failed *waiter.FailedStateError
+	if errors.As(err, &failed) {
+		if task, ok := result.(*clusterUpgradeTask); ok && task.Status.Message != "" {
+			return fmt.Errorf("%w: %s", err, task.Status.Message)
+		}
+	}
+	return err
+}
+
+func cceClusterUpgradeTaskRefreshFunc(client *golangsdk.ServiceClient, clusterID, operation, taskID string) waiter.RefreshFunc {
+	return func() (interface{}, string, error) {
+		task := new(clusterUpgradeTask)
+		_, err := client.Get(client.ServiceURL("clusters", clusterID, "operation", operation, "tasks", taskID), task, &golangsdk.RequestOpts{
+			OkCodes:     []int{200},
+			MoreHeaders: clusters.RequestOpts.MoreHeaders,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		return task, task.Status.Phase, nil
+	}
+}
+
+// cceClusterNodesRefreshFunc reports the state of the cluster nodes skipping the nodes failed before the upgrade
+func cceClusterNodesRefreshFunc(client *golangsdk.ServiceClient, clusterID string, skipped map[string]bool) waiter.RefreshFunc {
+	return func() (interface{}, string, error) {
+		clusterNodes, err := nodes.List(client, clusterID, nodes.ListOpts{})
+		if err != nil {
+			return nil, "", err
+		}
+		return clusterNodes, clusterNodesState(clusterNodes, skipped), nil
+	}
+}
+
+// clusterNodesState returns `Active` when all the nodes are active, `Error` when any node is failed,
+// otherwise the state of the first node which is not active. Skipped nodes are ignored.
+func clusterNodesState(clusterNodes []nodes.Nodes, skipped map[string]bool) string {
+	state := "Active"
+	for _, node := range clusterNodes {
+		if skipped[node.Metadata.Id] {
+			continue
+		}
+		switch node.Status.Phase {
+		case "Active":
+		case "Error":
+			return node.Status.Phase
+		default:
+			if state == "Active" {
+				state = node.Status.Phase
+			}
+		}
+	}
+	return state
+}
+
+// upgradeCCEClusterAddons upgrades installed addons of the cluster to the latest stable
+// versions supporting the current cluster version, keeping values of the addons
+func upgradeCCEClusterAddons(ctx context.Context, d *schema.ResourceData, config *cfg.Config, client *golangsdk.ServiceClient) error {
+	region := config.GetRegion(d)
+	addonClient, err := config.CceV3AddonClient(region)
+	if err != nil {
+		return fmt.Errorf("error creating CCE Addon client: %w", logHttpError(err))
+	}
+	cluster, err := clusters.Get(client, d.Id()).Extract()
+	if err != nil {
+		return fmt.Errorf("error retrieving CCE cluster: %w", err)
+	}
+	templates, err := getAddonTemplates(config, region, d.Id())
+	if err != nil {
+		return err
+	}
+
+	for _, raw := range d.Get("installed_addons").(*schema.Set).List() {
+		addonID := raw.(string)
+		addon, err := addons.Get(addonClient, addonID, d.Id()).Extract()
+		if err != nil {
+			return fmt.Errorf("error retrieving CCE addon %s: %w", addonID, err)
+		}
+		template, err := findAddonTemplate(templates, addon.Spec.AddonTemplateName)
+		if err != nil {
+			return err
+		}
+		templateVersion := findCompatibleAddonVersion(template, cluster.Spec.Type, cluster.Spec.Version)
+		if templateVersion == nil {
+			log.Printf("[WARN] No version of CCE addon %s supports cluster version %s",
+				addon.Spec.AddonTemplateName, cluster.Spec.Version)
+			continue
+		}
+		current, err := version.NewVersion(addon.Spec.Version)
+		if err != nil {
+			return fmt.Errorf("error parsing version of CCE addon %s: %w", addonID, err)
+		}
+		if compatible, _ := version.NewVersion(templateVersion.Version); !compatible.GreaterThan(current) {
+			continue
+		}
+
+		defaults, _ := addonTemplateInputs(templateVersion)
+		basic := make(map[string]interface{}, len(defaults))
+		for key, value := range defaults {
+			basic[key] = value
+		}
+		for key, value := range addon.Spec.Values.Basic {
+			basic[key] = value
+		}
+
+		log.Printf("[DEBUG] Upgrading CCE addon %s (%s) from %s to %s",
+			addonID, addon.Spec.AddonTemplateName, addon.Spec.Version, templateVersion.Version)
+		_, err = addons.Update(addonClient, addonID, d.Id(), addons.UpdateOpts{
+			Kind:       "Addon",
+			ApiVersion: "v3",
+			Metadata: addons.UpdateMetadata{
+				Annotations: addons.UpdateAnnotations{
+					AddonUpdateType: "upgrade",
+				},
+			},
+			Spec: addons.RequestSpec{
+				Version:           templateVersion.Version,
+				ClusterID:         d.Id(),
+				AddonTemplateName: addon.Spec.AddonTemplateName,
+				Values: addons.Values{
+					Basic:    basic,
+					Advanced: addon.Spec.Values.Advanced,
+				},
+			},
+		}).Extract()
+		if err != nil {
+			return fmt.Errorf("error upgrading CCE addon %s: %w", addonID, logHttpError(err))
+		}
+
+		stateConf := &waiter.Config{
+			Description: fmt.Sprintf("CCE addon %s", addonID),
+			Target:      []string{"running"},
+			Error:       []string{"upgradeFailed", "rollbackFailed"},
+			Refresh:     cceAddonUpgradeRefreshFunc(addonClient, addonID, d.Id(), templateVersion.Version),
+			Timeout:     d.Timeout(schema.TimeoutUpdate),
+			Delay:       5 * time.Second,
+			MinInterval: 5 * time.Second,
+		}
+		if _, err := stateConf.Wait(ctx); err != nil {
+			return fmt.Errorf("error waiting for CCE addon %s to be upgraded: %w", addonID, err)
+		}
+	}
+	return nil
+}
+
+// findCompatibleAddonVersion returns the latest stable version of the addon template
+// supporting the given cluster type and version
+func findCompatibleAddonVersion(template *addons.AddonTemplate, clusterType, clusterVersion string) *addons.Version {
+	var latest *addons.Version
+	var latestVersion *version.Version
+	for i, templateVersion := range template.Spec.Versions {
+		if !templateVersion.Stable || !addonSupportsCluster(templateVersion, clusterType, clusterVersion) {
+			continue
+		}
+		v, err := version.NewVersion(templateVersion.Version)
+		if err != nil {
+			continue
+		}
+		if latest == nil || v.GreaterThan(latestVersion) {
+			latest, latestVersion = &template.Spec.Versions[i], v
+		}
+	}
+	return latest
+}
+
+// addonSupportsCluster checks the cluster version against the regular expressions of the supported versions
+func addonSupportsCluster(templateVersion addons.Version, clusterType, clusterVersion string) bool {
+	for _, supported := range templateVersion.SupportVersions {
+		if supported.ClusterType != clusterType {
+			continue
+		}
+		for _, expr := range supported.ClusterVersion {
+			re, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", expr))
+			if err != nil {
+				log.Printf("[WARN] Invalid supported cluster version `%s` of CCE addon: %s", expr, err)
+				continue
+			}
+			if re.MatchString(clusterVersion) {
+				return true
+			}
+		}
+	}
+	return false
+}
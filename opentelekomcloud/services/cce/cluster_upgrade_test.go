@@ -0,0 +1,39 @@
+package cce
+
+import (
+	"testing"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/nodes"
+	th "github.com/opentelekomcloud/gophertelekomcloud/testhelper"
+)
+
+func testNodes(phases ...string) []nodes.Nodes {
+	clusterNodes := make([]nodes.Nodes, len(phases))
+	for i, phase := range phases {
+		clusterNodes[i].Metadata.Id = string(rune('a' + i))
+		clusterNodes[i].Status.Phase = phase
+	}
+	return clusterNodes
+}
+
+func TestClusterNodesState(t *testing.T) {
+	cases := []struct {
+		name     string
+		nodes    []nodes.Nodes
+		skipped  map[string]bool
+		expected string
+	}{
+		{"none", nil, nil, "Active"},
+		{"active", testNodes("Active", "Active"), nil, "Active"},
+		{"upgrading", testNodes("Active", "Upgrading", "Installing"), nil, "Upgrading"},
+		{"failed", testNodes("Upgrading", "Error"), nil, "Error"},
+		{"failed before", testNodes("Active", "Error"), map[string]bool{"b": true}, "Active"},
+		{"upgrading with failed before", testNodes("Error", "Upgrading"), map[string]bool{"a": true}, "Upgrading"},
+		{"failed during", testNodes("Error", "Error"), map[string]bool{"a": true}, "Error"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			th.AssertEquals(t, c.expected, clusterNodesState(c.nodes, c.skipped))
+		})
+	}
+}
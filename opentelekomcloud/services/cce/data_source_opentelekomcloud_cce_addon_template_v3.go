@@ -0,0 +1,206 @@
+package cce
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/addons"
+
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/cfg"
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/fmterr"
+)
+
+func DataSourceCCEAddonTemplateV3() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCCEAddonTemplateV3Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"addon_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"addon_version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"stable": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"available_versions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"basic_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"custom_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceCCEAddonTemplateV3Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*cfg.Config)
+	region := config.GetRegion(d)
+	clusterID := d.Get("cluster_id").(string)
+
+	templates, err := getAddonTemplates(config, region, clusterID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	template, err := findAddonTemplate(templates, d.Get("addon_name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	templateVersion, err := findAddonTemplateVersion(template, d.Get("addon_version").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	basic, custom := addonTemplateInputs(templateVersion)
+	basicJSON, err := json.Marshal(basic)
+	if err != nil {
+		return fmterr.Errorf("error marshalling basic input: %w", err)
+	}
+	customJSON, err := json.Marshal(custom)
+	if err != nil {
+		return fmterr.Errorf("error marshalling custom input: %w", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", clusterID, template.Metadata.Name, templateVersion.Version))
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("addon_version", templateVersion.Version),
+		d.Set("description", template.Spec.Description),
+		d.Set("type", template.Spec.Type),
+		d.Set("stable", templateVersion.Stable),
+		d.Set("available_versions", addonTemplateVersions(template)),
+		d.Set("basic_json", string(basicJSON)),
+		d.Set("custom_json", string(customJSON)),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return fmterr.Errorf("error setting addon template attributes: %w", err)
+	}
+
+	return nil
+}
+
+// getAddonTemplates returns addon templates available for the cluster, the list is loaded once per run
+func getAddonTemplates(config *cfg.Config, region, clusterID string) ([]addons.AddonTemplate, error) {
+	key := fmt.Sprintf("cce-addon-templates/%s/%s", region, clusterID)
+	templates, err := config.CachedLookup(key, func() (interface{}, error) {
+		client, err := config.CceV3AddonClient(region)
+		if err != nil {
+			return nil, fmt.Errorf("error creating CCE client: %w", err)
+		}
+		list, err := addons.ListTemplates(client, clusterID, nil).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("error listing CCE addon templates: %w", logHttpError(err))
+		}
+		return list.Items, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return templates.([]addons.AddonTemplate), nil
+}
+
+func findAddonTemplate(templates []addons.AddonTemplate, name string) (*addons.AddonTemplate, error) {
+	names := make([]string, 0, len(templates))
+	for i, template := range templates {
+		if template.Metadata.Name == name {
+			return &templates[i], nil
+		}
+		names = append(names, template.Metadata.Name)
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("can't find addon template `%s`, available templates: %s", name, strings.Join(names, ", "))
+}
+
+// findAddonTemplateVersion returns the given version of the template or,
+// if no version is given, the latest stable one
+func findAddonTemplateVersion(template *addons.AddonTemplate, templateVersion string) (*addons.Version, error) {
+	versions := template.Spec.Versions
+	if templateVersion != "" {
+		for i := range versions {
+			if versions[i].Version == templateVersion {
+				return &versions[i], nil
+			}
+		}
+		return nil, fmt.Errorf("can't find version `%s` of addon template `%s`, available versions: %s",
+			templateVersion, template.Metadata.Name, strings.Join(addonTemplateVersions(template), ", "))
+	}
+
+	var latest *addons.Version
+	var latestVersion *version.Version
+	for i := range versions {
+		v, err := version.NewVersion(versions[i].Version)
+		if err != nil {
+			continue
+		}
+		if latest != nil && latest.Stable && !versions[i].Stable {
+			continue
+		}
+		if latest == nil || (versions[i].Stable && !latest.Stable) || v.GreaterThan(latestVersion) {
+			latest, latestVersion = &versions[i], v
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no versions found for addon template `%s`", template.Metadata.Name)
+	}
+	return latest, nil
+}
+
+func addonTemplateVersions(template *addons.AddonTemplate) []string {
+	versions := make([]string, len(template.Spec.Versions))
+	for i, v := range template.Spec.Versions {
+		versions[i] = v.Version
+	}
+	return versions
+}
+
+// addonTemplateInputs returns default `basic` and `custom` values of the template version
+func addonTemplateInputs(templateVersion *addons.Version) (basic, custom map[string]interface{}) {
+	basic, _ = templateVersion.Input["basic"].(map[string]interface{})
+	if parameters, ok := templateVersion.Input["parameters"].(map[string]interface{}); ok {
+		custom, _ = parameters["custom"].(map[string]interface{})
+	}
+	if basic == nil {
+		basic = make(map[string]interface{})
+	}
+	if custom == nil {
+		custom = make(map[string]interface{})
+	}
+	return
+}
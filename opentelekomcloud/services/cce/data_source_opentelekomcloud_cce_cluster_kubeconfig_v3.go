@@ -0,0 +1,293 @@
+package cce
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/clusters"
+	"gopkg.in/yaml.v2"
+
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/cfg"
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/fmterr"
+)
+
+const (
+	kubeContextInternal    = "internal"
+	kubeContextExternal    = "external"
+	kubeContextExternalOTC = "external_otc"
+
+	// kubeContextExternalTLSVerify is the external context of the certificate verifiable by the cluster CA
+	kubeContextExternalTLSVerify = "externalTLSVerify"
+)
+
+func DataSourceCCEClusterKubeConfigV3() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCCEClusterKubeConfigV3Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"context": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  kubeContextInternal,
+				ValidateFunc: validation.StringInSlice([]string{
+					kubeContextInternal, kubeContextExternal, kubeContextExternalOTC,
+				}, false),
+			},
+			"duration": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 1825),
+			},
+			"kubeconfig": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"host": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cluster_ca_certificate": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"client_certificate": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"client_key": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func dataSourceCCEClusterKubeConfigV3Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*cfg.Config)
+	region := config.GetRegion(d)
+	client, err := config.CceV3Client(region)
+	if err != nil {
+		return fmterr.Errorf(cceClientError, err)
+	}
+
+	clusterID := d.Get("cluster_id").(string)
+	cert, err := getCCEClusterCert(client, clusterID, d.Get("duration").(int))
+	if err != nil {
+		return fmterr.Errorf("error retrieving CCE cluster certificate: %w", err)
+	}
+
+	contextName := d.Get("context").(string)
+	externalOTCEndpoint := ""
+	if contextName == kubeContextExternalOTC {
+		cluster, err := clusters.Get(client, clusterID).Extract()
+		if err != nil {
+			return fmterr.Errorf("error retrieving CCE cluster: %w", err)
+		}
+		if len(cluster.Status.Endpoints) != 0 {
+			externalOTCEndpoint = cluster.Status.Endpoints[0].ExternalOTC
+		}
+	}
+
+	kubeConfig, err := buildKubeConfig(cert, contextName, externalOTCEndpoint)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rendered, err := yaml.Marshal(kubeConfig)
+	if err != nil {
+		return fmterr.Errorf("error rendering kubeconfig: %w", err)
+	}
+
+	kubeCluster := kubeConfig.Clusters[0].Cluster
+	kubeUser := kubeConfig.Users[0].User
+	caCert, err := base64.StdEncoding.DecodeString(kubeCluster.CertificateAuthorityData)
+	if err != nil {
+		return fmterr.Errorf("error decoding cluster CA certificate: %w", err)
+	}
+	clientCert, err := base64.StdEncoding.DecodeString(kubeUser.ClientCertificateData)
+	if err != nil {
+		return fmterr.Errorf("error decoding client certificate: %w", err)
+	}
+	clientKey, err := base64.StdEncoding.DecodeString(kubeUser.ClientKeyData)
+	if err != nil {
+		return fmterr.Errorf("error decoding client key: %w", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", clusterID, contextName))
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("kubeconfig", string(rendered)),
+		d.Set("host", kubeCluster.Server),
+		d.Set("cluster_ca_certificate", string(caCert)),
+		d.Set("client_certificate", string(clientCert)),
+		d.Set("client_key", string(clientKey)),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return fmterr.Errorf("error setting kubeconfig attributes: %w", err)
+	}
+
+	return nil
+}
+
+// getCCEClusterCert returns the cluster certificate. If duration is set, new certificate
+// valid for the given number of days is requested.
+func getCCEClusterCert(client *golangsdk.ServiceClient, clusterID string, duration int) (*clusters.Certificate, error) {
+	if duration == 0 {
+		return clusters.GetCert(client, clusterID).Extract()
+	}
+
+	var r clusters.GetCertResult
+	body := map[string]interface{}{"duration": duration}
+	_, r.Err = client.Post(client.ServiceURL("clusters", clusterID, "clustercert"), body, &r.Body, &golangsdk.RequestOpts{
+		OkCodes:     []int{200, 201},
+		MoreHeaders: clusters.RequestOpts.MoreHeaders,
+	})
+	return r.Extract()
+}
+
+type kubeConfig struct {
+	APIVersion     string                 `yaml:"apiVersion"`
+	Kind           string                 `yaml:"kind"`
+	Preferences    map[string]interface{} `yaml:"preferences"`
+	Clusters       []kubeConfigCluster    `yaml:"clusters"`
+	Users          []kubeConfigUser       `yaml:"users"`
+	Contexts       []kubeConfigContext    `yaml:"contexts"`
+	CurrentContext string                 `yaml:"current-context"`
+}
+
+type kubeConfigCluster struct {
+	Name    string                `yaml:"name"`
+	Cluster kubeConfigClusterData `yaml:"cluster"`
+}
+
+type kubeConfigClusterData struct {
+	Server                   string `yaml:"server"`
+	CertificateAuthorityData string `yaml:"certificate-authority-data,omitempty"`
+	InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify,omitempty"`
+}
+
+type kubeConfigUser struct {
+	Name string             `yaml:"name"`
+	User kubeConfigUserData `yaml:"user"`
+}
+
+type kubeConfigUserData struct {
+	ClientCertificateData string `yaml:"client-certificate-data"`
+	ClientKeyData         string `yaml:"client-key-data"`
+}
+
+type kubeConfigContext struct {
+	Name    string                `yaml:"name"`
+	Context kubeConfigContextData `yaml:"context"`
+}
+
+type kubeConfigContextData struct {
+	Cluster string `yaml:"cluster"`
+	User    string `yaml:"user"`
+}
+
+// buildKubeConfig builds kubeconfig with the single context from the cluster certificate
+func buildKubeConfig(cert *clusters.Certificate, contextName, externalOTCEndpoint string) (*kubeConfig, error) {
+	var certContext *clusters.CertContext
+	switch contextName {
+	case kubeContextExternal:
+		certContext = findCertContext(cert, kubeContextExternalTLSVerify)
+		if certContext == nil {
+			certContext = findCertContext(cert, kubeContextExternal)
+		}
+	case kubeContextExternalOTC:
+		// API gateway accepts the same credentials as the cluster itself
+		certContext = findCertContext(cert, kubeContextInternal)
+	default:
+		certContext = findCertContext(cert, contextName)
+	}
+	if certContext == nil {
+		return nil, fmt.Errorf("context `%s` is not available for the cluster, make sure the cluster has the endpoint", contextName)
+	}
+
+	var cluster *kubeConfigCluster
+	for _, c := range cert.Clusters {
+		if c.Name == certContext.Cluster {
+			cluster = &kubeConfigCluster{
+				Name: c.Name,
+				Cluster: kubeConfigClusterData{
+					Server:                   c.Cluster.Server,
+					CertificateAuthorityData: c.Cluster.CertAuthorityData,
+					// same as in the kubeconfig provided by CCE for the endpoint not covered by the cluster CA
+					InsecureSkipTLSVerify: c.Cluster.CertAuthorityData == "",
+				},
+			}
+			break
+		}
+	}
+	if cluster == nil {
+		return nil, fmt.Errorf("cluster `%s` of context `%s` is missing in the certificate", certContext.Cluster, contextName)
+	}
+	if contextName == kubeContextExternalOTC {
+		if externalOTCEndpoint == "" {
+			return nil, fmt.Errorf("cluster has no `%s` endpoint", kubeContextExternalOTC)
+		}
+		cluster = &kubeConfigCluster{
+			Name:    "externalOTCCluster",
+			Cluster: kubeConfigClusterData{Server: externalOTCEndpoint},
+		}
+	}
+
+	var user *kubeConfigUser
+	for _, u := range cert.Users {
+		if u.Name == certContext.User {
+			user = &kubeConfigUser{
+				Name: u.Name,
+				User: kubeConfigUserData{
+					ClientCertificateData: u.User.ClientCertData,
+					ClientKeyData:         u.User.ClientKeyData,
+				},
+			}
+			break
+		}
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user `%s` of context `%s` is missing in the certificate", certContext.User, contextName)
+	}
+
+	return &kubeConfig{
+		APIVersion:  "v1",
+		Kind:        "Config",
+		Preferences: map[string]interface{}{},
+		Clusters:    []kubeConfigCluster{*cluster},
+		Users:       []kubeConfigUser{*user},
+		Contexts: []kubeConfigContext{
+			{
+				Name:    contextName,
+				Context: kubeConfigContextData{Cluster: cluster.Name, User: user.Name},
+			},
+		},
+		CurrentContext: contextName,
+	}, nil
+}
+
+func findCertContext(cert *clusters.Certificate, name string) *clusters.CertContext {
+	for i := range cert.Contexts {
+		if cert.Contexts[i].Name == name {
+			return &cert.Contexts[i].Context
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package cce
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/clusters"
+	th "github.com/opentelekomcloud/gophertelekomcloud/testhelper"
+	"gopkg.in/yaml.v2"
+)
+
+// testClusterCert is the certificate of the cluster with the EIP bound, as returned by CCE
+const testClusterCert = `
+{
+  "kind": "Config",
+  "apiVersion": "v1",
+  "preferences": {},
+  "clusters": [
+    {"name": "internalCluster", "cluster": {"server": "https://192.168.0.10:5443", "certificate-authority-data": "Y2EtZGF0YQ=="}},
+    {"name": "externalCluster", "cluster": {"server": "https://80.158.1.1:5443", "insecure-skip-tls-verify": true}},
+    {"name": "externalClusterTLSVerify", "cluster": {"server": "https://80.158.1.1:5443", "certificate-authority-data": "Y2EtZGF0YQ=="}}
+  ],
+  "users": [
+    {"name": "user", "user": {"client-certificate-data": "Y2VydA==", "client-key-data": "a2V5"}}
+  ],
+  "contexts": [
+    {"name": "internal", "context": {"cluster": "internalCluster", "user": "user"}},
+    {"name": "external", "context": {"cluster": "externalCluster", "user": "user"}},
+    {"name": "externalTLSVerify", "context": {"cluster": "externalClusterTLSVerify", "user": "user"}}
+  ],
+  "current-context": "external"
+}
+`
+
+func testCert(t *testing.T, withoutContexts ...string) *clusters.Certificate {
+	cert := new(clusters.Certificate)
+	th.AssertNoErr(t, json.Unmarshal([]byte(testClusterCert), cert))
+	var contexts []clusters.CertContexts
+	for _, c := range cert.Contexts {
+		skip := false
+		for _, name := range withoutContexts {
+			skip = skip || c.Name == name
+		}
+		if !skip {
+			contexts = append(contexts, c)
+		}
+	}
+	cert.Contexts = contexts
+	return cert
+}
+
+func TestBuildKubeConfig(t *testing.T) {
+	cases := []struct {
+		name            string
+		cert            *clusters.Certificate
+		context         string
+		externalOTC     string
+		expectedCluster string
+	}{
+		{
+			name:    "internal",
+			cert:    testCert(t),
+			context: kubeContextInternal,
+			expectedCluster: `- name: internalCluster
+  cluster:
+    server: https://192.168.0.10:5443
+    certificate-authority-data: Y2EtZGF0YQ==`,
+		},
+		{
+			name:    "external verified by cluster CA",
+			cert:    testCert(t),
+			context: kubeContextExternal,
+			expectedCluster: `- name: externalClusterTLSVerify
+  cluster:
+    server: https://80.158.1.1:5443
+    certificate-authority-data: Y2EtZGF0YQ==`,
+		},
+		{
+			name:    "external without cluster CA",
+			cert:    testCert(t, kubeContextExternalTLSVerify),
+			context: kubeContextExternal,
+			expectedCluster: `- name: externalCluster
+  cluster:
+    server: https://80.158.1.1:5443
+    insecure-skip-tls-verify: true`,
+		},
+		{
+			name:        "external OTC",
+			cert:        testCert(t, kubeContextExternal, kubeContextExternalTLSVerify),
+			context:     kubeContextExternalOTC,
+			externalOTC: "https://cluster-id.cce.eu-de.otc.t-systems.com",
+			expectedCluster: `- name: externalOTCCluster
+  cluster:
+    server: https://cluster-id.cce.eu-de.otc.t-systems.com`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config, err := buildKubeConfig(c.cert, c.context, c.externalOTC)
+			th.AssertNoErr(t, err)
+			rendered, err := yaml.Marshal(config)
+			th.AssertNoErr(t, err)
+
+			clusterName := config.Clusters[0].Name
+			expected := `apiVersion: v1
+kind: Config
+preferences: {}
+clusters:
+` + c.expectedCluster + `
+users:
+- name: user
+  user:
+    client-certificate-data: Y2VydA==
+    client-key-data: a2V5
+contexts:
+- name: ` + c.context + `
+  context:
+    cluster: ` + clusterName + `
+    user: user
+current-context: ` + c.context + `
+`
+			th.AssertEquals(t, expected, string(rendered))
+		})
+	}
+}
+
+func TestBuildKubeConfigErrors(t *testing.T) {
+	cases := []struct {
+		name        string
+		cert        *clusters.Certificate
+		context     string
+		externalOTC string
+		err         string
+	}{
+		{
+			name:    "no external endpoint",
+			cert:    testCert(t, kubeContextExternal, kubeContextExternalTLSVerify),
+			context: kubeContextExternal,
+			err:     "context `external` is not available for the cluster, make sure the cluster has the endpoint",
+		},
+		{
+			name:    "no external OTC endpoint",
+			cert:    testCert(t),
+			context: kubeContextExternalOTC,
+			err:     "cluster has no `external_otc` endpoint",
+		},
+		{
+			name:    "no internal context for external OTC",
+			cert:    testCert(t, kubeContextInternal),
+			context: kubeContextExternalOTC,
+			err:     "context `external_otc` is not available for the cluster, make sure the cluster has the endpoint",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := buildKubeConfig(c.cert, c.context, c.externalOTC)
+			if err == nil {
+				t.Fatalf("expected error: %s", c.err)
+			}
+			th.AssertEquals(t, c.err, err.Error())
+		})
+	}
+}
@@ -4,27 +4,31 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/addons"
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/cfg"
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/fmterr"
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/waiter"
 )
 
 func ResourceCCEAddonV3() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceCCEAddonV3Create,
 		ReadContext:   resourceCCEAddonV3Read,
+		UpdateContext: resourceCCEAddonV3Update,
 		DeleteContext: resourceCCEAddonV3Delete,
 
 		Timeouts: &schema.ResourceTimeout{
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 
@@ -32,11 +36,12 @@ func ResourceCCEAddonV3() *schema.Resource {
 			StateContext: resourceCCEAddonV3Import,
 		},
 
+		CustomizeDiff: validateCCEAddonValues,
+
 		Schema: map[string]*schema.Schema{
 			"template_version": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 			"cluster_id": {
 				Type:     schema.TypeString,
@@ -60,18 +65,15 @@ func ResourceCCEAddonV3() *schema.Resource {
 				Type:     schema.TypeList,
 				Required: true,
 				MaxItems: 1,
-				ForceNew: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"basic": {
 							Type:     schema.TypeMap,
 							Required: true,
-							ForceNew: true,
 						},
 						"custom": {
 							Type:     schema.TypeMap,
 							Required: true,
-							ForceNew: true,
 						},
 					},
 				},
@@ -93,10 +95,10 @@ func resourceCCEAddonV3Create(ctx context.Context, d *schema.ResourceData, meta
 		return fmterr.Errorf("error getting values for CCE addon: %w", err)
 	}
 
-	basic = unStringMap(basic)
+	templateName := d.Get("template_name").(string)
+	basic = addonBasicWithDefaults(config, d, unStringMap(basic))
 	custom = unStringMap(custom)
 
-	templateName := d.Get("template_name").(string)
 	addon, err := addons.Create(client, addons.CreateOpts{
 		Kind:       "Addon",
 		ApiVersion: "v3",
@@ -163,6 +165,63 @@ func resourceCCEAddonV3Read(_ context.Context, d *schema.ResourceData, meta inte
 	return nil
 }
 
+func resourceCCEAddonV3Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*cfg.Config)
+	client, err := config.CceV3AddonClient(config.GetRegion(d))
+	if err != nil {
+		return fmterr.Errorf("error creating CCE client: %w", err)
+	}
+
+	clusterID := d.Get("cluster_id").(string)
+	basic, custom, err := getAddonValues(d)
+	if err != nil {
+		return fmterr.Errorf("error getting values for CCE addon: %w", err)
+	}
+
+	templateName := d.Get("template_name").(string)
+	_, err = addons.Update(client, d.Id(), clusterID, addons.UpdateOpts{
+		Kind:       "Addon",
+		ApiVersion: "v3",
+		Metadata: addons.UpdateMetadata{
+			Annotations: addons.UpdateAnnotations{
+				AddonUpdateType: "upgrade",
+			},
+		},
+		Spec: addons.RequestSpec{
+			Version:           d.Get("template_version").(string),
+			ClusterID:         clusterID,
+			AddonTemplateName: templateName,
+			Values: addons.Values{
+				Basic:    addonBasicWithDefaults(config, d, unStringMap(basic)),
+				Advanced: unStringMap(custom),
+			},
+		},
+	}).Extract()
+	if err != nil {
+		errMsg := logHttpError(err)
+		addonSpec, aErr := getAddonTemplateSpec(client, clusterID, templateName)
+		if aErr == nil {
+			errMsg = fmt.Errorf("\nAddon template spec: %s\n%s", addonSpec, errMsg)
+		}
+		return fmterr.Errorf("error updating CCE addon instance: %w", errMsg)
+	}
+
+	stateConf := &waiter.Config{
+		Description: fmt.Sprintf("CCE addon %s", d.Id()),
+		Target:      []string{"running"},
+		Error:       []string{"upgradeFailed", "rollbackFailed"},
+		Refresh:     cceAddonUpgradeRefreshFunc(client, d.Id(), clusterID, d.Get("template_version").(string)),
+		Timeout:     d.Timeout(schema.TimeoutUpdate),
+		Delay:       5 * time.Second,
+		MinInterval: 5 * time.Second,
+	}
+	if _, err := stateConf.Wait(ctx); err != nil {
+		return fmterr.Errorf("error waiting for CCE addon to be upgraded: %w", err)
+	}
+
+	return resourceCCEAddonV3Read(ctx, d, meta)
+}
+
 func getAddonValues(d *schema.ResourceData) (basic, custom map[string]interface{}, err error) {
 	valLength := d.Get("values.#").(int)
 	if valLength == 0 {
@@ -174,6 +233,40 @@ func getAddonValues(d *schema.ResourceData) (basic, custom map[string]interface{
 	return
 }
 
+// addonBasicWithDefaults returns `basic` values extended with the template defaults missing in them,
+// e.g. `swr_addr` and `cceEndpoint` differing between the regions
+func addonBasicWithDefaults(config *cfg.Config, d *schema.ResourceData, basic map[string]interface{}) map[string]interface{} {
+	templates, err := getAddonTemplates(config, config.GetRegion(d), d.Get("cluster_id").(string))
+	if err != nil {
+		log.Printf("[WARN] Addon template defaults are not used: %s", err)
+		return basic
+	}
+	template, err := findAddonTemplate(templates, d.Get("template_name").(string))
+	if err != nil {
+		log.Printf("[WARN] Addon template defaults are not used: %s", err)
+		return basic
+	}
+	templateVersion, err := findAddonTemplateVersion(template, d.Get("template_version").(string))
+	if err != nil {
+		log.Printf("[WARN] Addon template defaults are not used: %s", err)
+		return basic
+	}
+	defaults, _ := addonTemplateInputs(templateVersion)
+	return withAddonDefaults(basic, defaults)
+}
+
+// withAddonDefaults returns copy of the values with the defaults set for the missing keys
+func withAddonDefaults(values, defaults map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(defaults)+len(values))
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, value := range values {
+		merged[key] = value
+	}
+	return merged
+}
+
 func resourceCCEAddonV3Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*cfg.Config)
 	client, err := config.CceV3AddonClient(config.GetRegion(d))
@@ -182,14 +275,15 @@ func resourceCCEAddonV3Delete(ctx context.Context, d *schema.ResourceData, meta
 	}
 	clusterID := d.Get("cluster_id").(string)
 
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"available"},
-		Target:  []string{"deleted"},
-		Refresh: waitForCCEAddonDelete(client, d.Id(), clusterID),
-		Timeout: d.Timeout(schema.TimeoutDelete),
+	stateConf := &waiter.Config{
+		Description: fmt.Sprintf("CCE addon %s", d.Id()),
+		Pending:     []string{"available"},
+		Target:      []string{waiter.Deleted},
+		Refresh:     cceAddonDeleteRefreshFunc(client, d.Id(), clusterID),
+		Timeout:     d.Timeout(schema.TimeoutDelete),
 	}
 
-	_, err = stateConf.WaitForStateContext(ctx)
+	_, err = stateConf.Wait(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -235,27 +329,121 @@ func unStringMap(src map[string]interface{}) map[string]interface{} {
 	return out
 }
 
-func waitForCCEAddonDelete(client *golangsdk.ServiceClient, addonID, clusterID string) resource.StateRefreshFunc {
+// cceAddonUpgradeRefreshFunc reports the addon status, the addon still running the previous version
+// right after the upgrade request is reported as `upgrading`
+func cceAddonUpgradeRefreshFunc(client *golangsdk.ServiceClient, addonID, clusterID, version string) waiter.RefreshFunc {
+	return func() (interface{}, string, error) {
+		addon, err := addons.Get(client, addonID, clusterID).Extract()
+		if err != nil {
+			return nil, "", err
+		}
+		if addon.Status.Status == "running" && addon.Spec.Version != version {
+			return addon, "upgrading", nil
+		}
+		return addon, addon.Status.Status, nil
+	}
+}
+
+func cceAddonDeleteRefreshFunc(client *golangsdk.ServiceClient, addonID, clusterID string) waiter.RefreshFunc {
 	return func() (interface{}, string, error) {
 		if err := addons.Delete(client, addonID, clusterID).ExtractErr(); err != nil {
-			if _, ok := err.(golangsdk.ErrDefault404); ok {
-				return nil, "deleted", nil
+			if waiter.IsNotFound(err) {
+				return nil, waiter.Deleted, nil
 			}
 			return nil, "error", fmt.Errorf("error deleting CCE addon : %w", err)
 		}
 
 		addon, err := addons.Get(client, addonID, clusterID).Extract()
 		if err != nil {
-			if _, ok := err.(golangsdk.ErrDefault404); ok {
-				return addon, "deleted", nil
-			}
-			return nil, "error", fmt.Errorf("error waiting CCE addon to become deleted: %w", err)
+			return nil, "", err
 		}
 
 		return addon, "available", nil
 	}
 }
 
+// validateCCEAddonValues checks that the template version exists and `values` match the template inputs
+func validateCCEAddonValues(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChange("template_version") && !d.HasChange("values") {
+		return nil
+	}
+	clusterID := d.Get("cluster_id").(string)
+	if clusterID == "" || !d.NewValueKnown("cluster_id") {
+		return nil // cluster is not created yet
+	}
+
+	config := meta.(*cfg.Config)
+	templates, err := getAddonTemplates(config, config.GetRegion(d), clusterID)
+	if err != nil {
+		log.Printf("[WARN] Addon values are not validated: %s", err)
+		return nil
+	}
+	template, err := findAddonTemplate(templates, d.Get("template_name").(string))
+	if err != nil {
+		return err
+	}
+	templateVersion, err := findAddonTemplateVersion(template, d.Get("template_version").(string))
+	if err != nil {
+		return err
+	}
+	if !d.NewValueKnown("values") {
+		return nil
+	}
+
+	basic, custom := addonTemplateInputs(templateVersion)
+	mErr := multierror.Append(nil,
+		validateAddonInput(d, "basic", basic),
+		validateAddonInput(d, "custom", custom),
+	)
+	return mErr.ErrorOrNil()
+}
+
+// validateAddonInput checks that the values are known to the template and have the same type as template defaults.
+// Unknown `custom` values are only logged.
+func validateAddonInput(d *schema.ResourceDiff, kind string, defaults map[string]interface{}) error {
+	values := d.Get("values.0." + kind).(map[string]interface{})
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	mErr := &multierror.Error{}
+	for _, key := range keys {
+		if !d.NewValueKnown(fmt.Sprintf("values.0.%s.%s", kind, key)) {
+			continue
+		}
+		def, ok := defaults[key]
+		if !ok {
+			known := make([]string, 0, len(defaults))
+			for k := range defaults {
+				known = append(known, k)
+			}
+			sort.Strings(known)
+			// templates don't list all the custom inputs supported by the addon
+			if kind == "custom" {
+				log.Printf("[WARN] `custom` input `%s` has no default in the template, known inputs: %s", key, strings.Join(known, ", "))
+				continue
+			}
+			mErr = multierror.Append(mErr, fmt.Errorf("`%s` input `%s` is not supported by the template, supported inputs: %s",
+				kind, key, strings.Join(known, ", ")))
+			continue
+		}
+		value := values[key].(string)
+		switch def.(type) {
+		case float64:
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				mErr = multierror.Append(mErr, fmt.Errorf("`%s` input `%s` must be a number, got %q", kind, key, value))
+			}
+		case bool:
+			if _, err := strconv.ParseBool(value); err != nil {
+				mErr = multierror.Append(mErr, fmt.Errorf("`%s` input `%s` must be a boolean, got %q", kind, key, value))
+			}
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
 func resourceCCEAddonV3Import(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	parts := strings.SplitN(d.Id(), "/", 2)
 	if len(parts) != 2 {
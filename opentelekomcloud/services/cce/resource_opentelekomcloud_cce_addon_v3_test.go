@@ -0,0 +1,34 @@
+package cce
+
+import (
+	"testing"
+
+	th "github.com/opentelekomcloud/gophertelekomcloud/testhelper"
+)
+
+func TestWithAddonDefaults(t *testing.T) {
+	defaults := map[string]interface{}{
+		"swr_addr":     "100.125.7.25:20202",
+		"swr_user":     "hwofficial",
+		"cceEndpoint":  "https://cce.eu-de.otc.t-systems.com",
+		"rbac_enabled": true,
+	}
+	values := map[string]interface{}{
+		"image_version": "v0.3.7",
+		"swr_user":      "custom",
+		"rbac_enabled":  false,
+	}
+
+	merged := withAddonDefaults(values, defaults)
+	th.AssertDeepEquals(t, map[string]interface{}{
+		"image_version": "v0.3.7",
+		"swr_addr":      "100.125.7.25:20202",
+		"swr_user":      "custom",
+		"cceEndpoint":   "https://cce.eu-de.otc.t-systems.com",
+		"rbac_enabled":  false,
+	}, merged)
+
+	// source maps are not modified
+	th.AssertEquals(t, 3, len(values))
+	th.AssertEquals(t, "hwofficial", defaults["swr_user"])
+}
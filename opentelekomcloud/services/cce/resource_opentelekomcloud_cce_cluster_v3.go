@@ -10,8 +10,8 @@ import (
 	"time"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
@@ -24,6 +24,7 @@ import (
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common"
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/cfg"
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/fmterr"
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/waiter"
 )
 
 var (
@@ -44,10 +45,14 @@ func ResourceCCEClusterV3() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(90 * time.Minute),
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 
-		CustomizeDiff: validateCCEClusterNetwork,
+		CustomizeDiff: common.MultipleCustomizeDiffs(
+			validateCCEClusterNetwork,
+			forceNewCCEClusterDowngrade,
+		),
 
 		Schema: map[string]*schema.Schema{
 			"region": {
@@ -83,7 +88,6 @@ func ResourceCCEClusterV3() *schema.Resource {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Computed:         true,
-				ForceNew:         true,
 				DiffSuppressFunc: common.SuppressSmartVersionDiff,
 			},
 			"cluster_type": {
@@ -226,6 +230,10 @@ func ResourceCCEClusterV3() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"upgrade_addons": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"installed_addons": {
 				Type:     schema.TypeSet,
 				Computed: true,
@@ -330,16 +338,18 @@ func resourceCCEClusterV3Create(ctx context.Context, d *schema.ResourceData, met
 
 	log.Printf("[DEBUG] Waiting for opentelekomcloud CCE cluster (%s) to become available", create.Metadata.Id)
 
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Creating"},
-		Target:     []string{"Available"},
-		Refresh:    waitForCCEClusterActive(cceClient, create.Metadata.Id),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
+	stateConf := &waiter.Config{
+		Description: fmt.Sprintf("CCE cluster %s", create.Metadata.Id),
+		Pending:     []string{"Creating"},
+		Target:      []string{"Available"},
+		Error:       []string{"Error"},
+		Refresh:     cceClusterRefreshFunc(cceClient, create.Metadata.Id),
+		Timeout:     d.Timeout(schema.TimeoutCreate),
+		Delay:       5 * time.Second,
+		MinInterval: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForStateContext(ctx)
+	_, err = stateConf.Wait(ctx)
 	if err != nil {
 		return fmterr.Errorf("error creating OpenTelekomCloud CCE cluster: %s", err)
 	}
@@ -510,6 +520,28 @@ func resourceCCEClusterV3Update(ctx context.Context, d *schema.ResourceData, met
 		}
 	}
 
+	if d.HasChange("cluster_version") {
+		if err := upgradeCCECluster(ctx, d, cceClient); err != nil {
+			// keep previous version in the state, so the upgrade is retried,
+			// other attributes are already updated and saved
+			oldVersion, _ := d.GetChange("cluster_version")
+			if setErr := d.Set("cluster_version", oldVersion); setErr != nil {
+				return fmterr.Errorf("error upgrading opentelekomcloud CCE cluster: %w, error setting cluster_version: %s", err, setErr)
+			}
+			return fmterr.Errorf("error upgrading opentelekomcloud CCE cluster: %w", err)
+		}
+	}
+	if d.Get("upgrade_addons").(bool) && d.HasChanges("cluster_version", "upgrade_addons") {
+		if err := upgradeCCEClusterAddons(ctx, d, config, cceClient); err != nil {
+			// the cluster is already upgraded, so the flag differing from the configuration
+			// makes the next apply retry the upgrade of the addons
+			if setErr := d.Set("upgrade_addons", false); setErr != nil {
+				return fmterr.Errorf("error upgrading opentelekomcloud CCE cluster addons: %w, error setting upgrade_addons: %s", err, setErr)
+			}
+			return fmterr.Errorf("error upgrading opentelekomcloud CCE cluster addons: %w", err)
+		}
+	}
+
 	return resourceCCEClusterV3Read(ctx, d, meta)
 }
 
@@ -523,16 +555,17 @@ func resourceCCEClusterV3Delete(ctx context.Context, d *schema.ResourceData, met
 	if err != nil {
 		return fmterr.Errorf("error deleting opentelekomcloud CCE Cluster: %w", err)
 	}
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Deleting", "Available", "Unavailable"},
-		Target:     []string{"Deleted"},
-		Refresh:    waitForCCEClusterDelete(cceClient, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
+	stateConf := &waiter.Config{
+		Description: fmt.Sprintf("CCE cluster %s", d.Id()),
+		// cluster can be in any phase, including `Error`, until it's removed
+		Target:      []string{waiter.Deleted},
+		Refresh:     cceClusterRefreshFunc(cceClient, d.Id()),
+		Timeout:     d.Timeout(schema.TimeoutDelete),
+		Delay:       5 * time.Second,
+		MinInterval: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForStateContext(ctx)
+	_, err = stateConf.Wait(ctx)
 
 	if err != nil {
 		return fmterr.Errorf("error deleting opentelekomcloud CCE cluster: %w", err)
@@ -542,36 +575,29 @@ func resourceCCEClusterV3Delete(ctx context.Context, d *schema.ResourceData, met
 	return nil
 }
 
-func waitForCCEClusterActive(cceClient *golangsdk.ServiceClient, clusterId string) resource.StateRefreshFunc {
+func cceClusterRefreshFunc(cceClient *golangsdk.ServiceClient, clusterId string) waiter.RefreshFunc {
 	return func() (interface{}, string, error) {
 		n, err := clusters.Get(cceClient, clusterId).Extract()
 		if err != nil {
-			return nil, "", fmt.Errorf("error waiting for CCE cluster to become active: %w", err)
+			return nil, "", err
 		}
-
 		return n, n.Status.Phase, nil
 	}
 }
 
-func waitForCCEClusterDelete(cceClient *golangsdk.ServiceClient, clusterId string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		log.Printf("[DEBUG] Attempting to delete  CCE cluster %s.\n", clusterId)
-
-		r, err := clusters.Get(cceClient, clusterId).Extract()
-
-		if err != nil {
-			if _, ok := err.(golangsdk.ErrDefault404); ok {
-				log.Printf("[DEBUG] Successfully deleted opentelekomcloud CCE cluster %s", clusterId)
-				return r, "Deleted", nil
-			}
-			return nil, "", fmt.Errorf("error waiting CCE cluster to become deleted: %w", err)
-		}
-		if r.Status.Phase == "Deleting" {
-			return r, "Deleting", nil
-		}
-		log.Printf("[DEBUG] opentelekomcloud CCE cluster %s still available.\n", clusterId)
-		return r, "Available", nil
-	}
+// waitForCCEClusterAvailable waits for the cluster to finish the ongoing operation, e.g. before changing its nodes
+func waitForCCEClusterAvailable(ctx context.Context, cceClient *golangsdk.ServiceClient, clusterId string, timeout time.Duration) error {
+	stateConf := &waiter.Config{
+		Description: fmt.Sprintf("CCE cluster %s", clusterId),
+		Target:      []string{"Available"},
+		Error:       []string{"Error"},
+		Refresh:     cceClusterRefreshFunc(cceClient, clusterId),
+		Timeout:     timeout,
+		Delay:       15 * time.Second,
+		MinInterval: 3 * time.Second,
+	}
+	_, err := stateConf.Wait(ctx)
+	return err
 }
 
 func resourceFloatingIPV2Exists(d *schema.ResourceData, meta interface{}, floatingIP string) (string, error) {
@@ -625,6 +651,29 @@ func validateCCEClusterNetwork(_ context.Context, d *schema.ResourceDiff, meta i
 	return nil
 }
 
+// forceNewCCEClusterDowngrade forces the new cluster on the version downgrade, version upgrade is done in place
+func forceNewCCEClusterDowngrade(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("cluster_version") {
+		return nil
+	}
+	oldRaw, newRaw := d.GetChange("cluster_version")
+	if common.SuppressSmartVersionDiff("cluster_version", oldRaw.(string), newRaw.(string), nil) {
+		return nil
+	}
+	oldVersion, err := version.NewVersion(oldRaw.(string))
+	if err != nil {
+		return nil
+	}
+	newVersion, err := version.NewVersion(newRaw.(string))
+	if err != nil {
+		return nil
+	}
+	if newVersion.LessThan(oldVersion) {
+		return d.ForceNew("cluster_version")
+	}
+	return nil
+}
+
 func listInstalledAddons(d *schema.ResourceData, config *cfg.Config) (*addons.AddonInstanceList, error) {
 	client, err := config.CceV3AddonClient(config.GetRegion(d))
 	if err != nil {
@@ -639,17 +688,19 @@ func waitForInstalledAddons(ctx context.Context, d *schema.ResourceData, config
 		return fmt.Errorf("error creating CCE Addon client: %w", logHttpError(err))
 	}
 	// First wait for addons to be assigned
-	stateConfExist := &resource.StateChangeConf{
-		Pending:    []string{"Deleted"},
-		Target:     []string{"Available"},
-		Refresh:    waitForCCEClusterAddonsState(client, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      30 * time.Second,
-		MinTimeout: 1 * time.Minute,
+	stateConfExist := &waiter.Config{
+		Description: fmt.Sprintf("addons of CCE cluster %s", d.Id()),
+		Pending:     []string{"Empty"},
+		Target:      []string{"Available"},
+		Refresh:     cceClusterAddonsRefreshFunc(client, d.Id()),
+		Timeout:     d.Timeout(schema.TimeoutCreate),
+		Delay:       30 * time.Second,
+		MinInterval: 30 * time.Second,
+		MaxInterval: time.Minute,
 	}
 
-	if _, err := stateConfExist.WaitForStateContext(ctx); err != nil {
-		return fmt.Errorf("error waiting for addons to be removed: %w", err)
+	if _, err := stateConfExist.Wait(ctx); err != nil {
+		return fmt.Errorf("error waiting for addons to be installed: %w", err)
 	}
 	return nil
 }
@@ -670,16 +721,17 @@ func removeAddons(ctx context.Context, d *schema.ResourceData, config *cfg.Confi
 			return fmt.Errorf("error deleting cluster addon %s/%s: %w", d.Id(), addonID, err)
 		}
 	}
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Available"},
-		Target:     []string{"Deleted"},
-		Refresh:    waitForCCEClusterAddonsState(client, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
+	stateConf := &waiter.Config{
+		Description: fmt.Sprintf("addons of CCE cluster %s", d.Id()),
+		Pending:     []string{"Available"},
+		Target:      []string{"Empty"},
+		Refresh:     cceClusterAddonsRefreshFunc(client, d.Id()),
+		Timeout:     d.Timeout(schema.TimeoutDelete),
+		Delay:       5 * time.Second,
+		MinInterval: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForStateContext(ctx)
+	_, err = stateConf.Wait(ctx)
 	if err != nil {
 		return fmt.Errorf("error waiting for addons to be removed: %w", err)
 	}
@@ -687,7 +739,7 @@ func removeAddons(ctx context.Context, d *schema.ResourceData, config *cfg.Confi
 	return nil
 }
 
-func waitForCCEClusterAddonsState(client *golangsdk.ServiceClient, clusterID string) resource.StateRefreshFunc {
+func cceClusterAddonsRefreshFunc(client *golangsdk.ServiceClient, clusterID string) waiter.RefreshFunc {
 	return func() (r interface{}, s string, err error) {
 		instances, err := addons.ListAddonInstances(client, clusterID).Extract()
 		if err != nil {
@@ -696,7 +748,7 @@ func waitForCCEClusterAddonsState(client *golangsdk.ServiceClient, clusterID str
 		if len(instances.Items) > 0 {
 			return instances, "Available", nil
 		}
-		return instances, "Deleted", nil
+		return instances, "Empty", nil
 	}
 }
 
@@ -5,12 +5,12 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
@@ -21,17 +21,28 @@ import (
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common"
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/cfg"
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/fmterr"
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/waiter"
 )
 
 const (
 	createError = "error creating Open Telekom Cloud CCE Node Pool: %w"
 	setError    = "error setting %s for CCE Node Pool: %w"
+
+	// nodePoolIDAnnotation is the annotation of the node containing ID of its pool
+	nodePoolIDAnnotation = "kubernetes.io/node-pool.id"
 )
 
 var (
 	// Cluster pool taint key and value is 1 to 63 characters starting with a letter or digit.
 	// Only letters, digits, hyphens (-), underscores (_), and periods (.) are allowed.
 	clusterPoolTaintRegex = regexp.MustCompile("^[a-zA-Z0-9_.-]{1,63}$")
+
+	// nodePoolReplaceFields are the node template fields which can't be updated in place.
+	// Changing them either recreates the pool or, with `rolling_update`, replaces its nodes gradually.
+	nodePoolReplaceFields = []string{
+		"flavor", "availability_zone", "os", "root_volume", "data_volumes",
+		"key_pair", "password", "subnet_id", "server_group_reference",
+	}
 )
 
 func ResourceCCENodePoolV3() *schema.Resource {
@@ -58,6 +69,8 @@ func ResourceCCENodePoolV3() *schema.Resource {
 			common.ValidateVolumeType("root_volume.*.volumetype"),
 			common.ValidateVolumeType("data_volumes.*.volumetype"),
 			common.ValidateSubnet("subnet_id"),
+			common.ValidateFlavor("availability_zone", "flavor"),
+			validateCCENodePoolRollingUpdate,
 		),
 
 		Schema: map[string]*schema.Schema{
@@ -68,7 +81,6 @@ func ResourceCCENodePoolV3() *schema.Resource {
 			"flavor": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 			"cluster_id": {
 				Type:     schema.TypeString,
@@ -78,7 +90,6 @@ func ResourceCCENodePoolV3() *schema.Resource {
 			"availability_zone": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				Default:  "random",
 			},
 			"os": {
@@ -89,7 +100,6 @@ func ResourceCCENodePoolV3() *schema.Resource {
 			"root_volume": {
 				Type:     schema.TypeList,
 				Required: true,
-				ForceNew: true,
 				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -111,7 +121,6 @@ func ResourceCCENodePoolV3() *schema.Resource {
 			"data_volumes": {
 				Type:     schema.TypeList,
 				Required: true,
-				ForceNew: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"size": {
@@ -126,7 +135,6 @@ func ResourceCCENodePoolV3() *schema.Resource {
 						"kms_id": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							ForceNew:    true,
 							DefaultFunc: schema.EnvDefaultFunc("OS_KMS_ID", nil),
 						},
 						"extend_param": {
@@ -150,7 +158,6 @@ func ResourceCCENodePoolV3() *schema.Resource {
 			"user_tags": {
 				Type:     schema.TypeMap,
 				Optional: true,
-				ForceNew: true,
 			},
 			"taints": {
 				Type:     schema.TypeList,
@@ -183,31 +190,26 @@ func ResourceCCENodePoolV3() *schema.Resource {
 			"key_pair": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ForceNew:     true,
 				ExactlyOneOf: []string{"password", "key_pair"},
 			},
 			"password": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ForceNew:     true,
 				Sensitive:    true,
 				ExactlyOneOf: []string{"password", "key_pair"},
 			},
 			"subnet_id": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 			},
 			"preinstall": {
 				Type:      schema.TypeString,
 				Optional:  true,
-				ForceNew:  true,
 				StateFunc: common.GetHashOrEmpty,
 			},
 			"postinstall": {
 				Type:      schema.TypeString,
 				Optional:  true,
-				ForceNew:  true,
 				StateFunc: common.GetHashOrEmpty,
 			},
 			"scale_enable": {
@@ -233,7 +235,27 @@ func ResourceCCENodePoolV3() *schema.Resource {
 			"server_group_reference": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
+			},
+			"rolling_update": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_surge": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"max_unavailable": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
 			},
 			"status": {
 				Type:     schema.TypeString,
@@ -248,13 +270,7 @@ func resourceCCENodePoolUserTags(d *schema.ResourceData) []tags.ResourceTag {
 	return common.ExpandResourceTags(tagRaw)
 }
 
-func resourceCCENodePoolV3Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*cfg.Config)
-	nodePoolClient, err := config.CceV3Client(config.GetRegion(d))
-	if err != nil {
-		return fmterr.Errorf(cceClientError, err)
-	}
-
+func resourceCCENodePoolV3CreateOpts(d *schema.ResourceData) nodepools.CreateOpts {
 	var base64PreInstall, base64PostInstall string
 	if v, ok := d.GetOk("preinstall"); ok {
 		base64PreInstall = common.InstallScriptEncode(v.(string))
@@ -275,7 +291,7 @@ func resourceCCENodePoolV3Create(ctx context.Context, d *schema.ResourceData, me
 		}
 	}
 
-	createOpts := nodepools.CreateOpts{
+	return nodepools.CreateOpts{
 		Kind:       "NodePool",
 		ApiVersion: "v3",
 		Metadata: nodepools.CreateMetaData{
@@ -317,16 +333,18 @@ func resourceCCENodePoolV3Create(ctx context.Context, d *schema.ResourceData, me
 			},
 		},
 	}
+}
 
+func resourceCCENodePoolV3Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*cfg.Config)
+	nodePoolClient, err := config.CceV3Client(config.GetRegion(d))
+	if err != nil {
+		return fmterr.Errorf(cceClientError, err)
+	}
+
+	createOpts := resourceCCENodePoolV3CreateOpts(d)
 	clusterId := d.Get("cluster_id").(string)
-	stateCluster := &resource.StateChangeConf{
-		Target:     []string{"Available"},
-		Refresh:    waitForClusterAvailable(nodePoolClient, clusterId),
-		Timeout:    d.Timeout(schema.TimeoutDefault),
-		Delay:      15 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-	if _, err := stateCluster.WaitForStateContext(ctx); err != nil {
+	if err := waitForCCEClusterAvailable(ctx, nodePoolClient, clusterId, d.Timeout(schema.TimeoutDefault)); err != nil {
 		return fmterr.Errorf("error waiting for cluster to be available: %w", err)
 	}
 
@@ -334,7 +352,7 @@ func resourceCCENodePoolV3Create(ctx context.Context, d *schema.ResourceData, me
 	pool, err := nodepools.Create(nodePoolClient, clusterId, createOpts).Extract()
 	if err != nil {
 		if _, ok := err.(golangsdk.ErrDefault403); ok {
-			if _, err := stateCluster.WaitForStateContext(ctx); err != nil {
+			if err := waitForCCEClusterAvailable(ctx, nodePoolClient, clusterId, d.Timeout(schema.TimeoutDefault)); err != nil {
 				return fmterr.Errorf("error waiting for cluster to be available: %w", err)
 			}
 			retried, err := nodepools.Create(nodePoolClient, clusterId, createOpts).Extract()
@@ -349,15 +367,17 @@ func resourceCCENodePoolV3Create(ctx context.Context, d *schema.ResourceData, me
 
 	d.SetId(pool.Metadata.Id)
 
-	stateConf := &resource.StateChangeConf{
-		Pending:      []string{"Synchronizing", "Synchronized"},
-		Target:       []string{""},
-		Refresh:      waitForCceNodePoolActive(nodePoolClient, clusterId, d.Id()),
-		Timeout:      d.Timeout(schema.TimeoutCreate),
-		Delay:        120 * time.Second,
-		PollInterval: 20 * time.Second,
-	}
-	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+	stateConf := &waiter.Config{
+		Description: fmt.Sprintf("CCE node pool %s", d.Id()),
+		Pending:     []string{"Synchronizing", "Synchronized"},
+		Target:      []string{""},
+		Error:       []string{"Error"},
+		Refresh:     cceNodePoolRefreshFunc(nodePoolClient, clusterId, d.Id()),
+		Timeout:     d.Timeout(schema.TimeoutCreate),
+		Delay:       120 * time.Second,
+		MinInterval: 20 * time.Second,
+	}
+	if _, err := stateConf.Wait(ctx); err != nil {
 		return fmterr.Errorf(createError, err)
 	}
 
@@ -455,13 +475,45 @@ func resourceCCENodePoolV3Update(ctx context.Context, d *schema.ResourceData, me
 	if err != nil {
 		return fmterr.Errorf(cceClientError, err)
 	}
-	updateOpts := nodepools.UpdateOpts{
+	clusterId := d.Get("cluster_id").(string)
+
+	poolId := d.Id()
+	if d.HasChanges(nodePoolReplaceFields...) {
+		newPoolId, err := resourceCCENodePoolV3RollingUpdate(ctx, d, nodePoolClient)
+		if newPoolId != "" {
+			// nodes are replaced, the rest of the update is applied to the new pool
+			d.SetId(newPoolId)
+			poolId = newPoolId
+		}
+		if err != nil {
+			// keep previous values of the node template in the state, so the replacement is retried,
+			// unless the nodes are already replaced and only the old pool is not deleted
+			if newPoolId == "" {
+				d.Partial(true)
+			}
+			return fmterr.Errorf("error replacing nodes of Open Telekom Cloud CCE Node Pool: %w", err)
+		}
+	}
+
+	var base64PreInstall, base64PostInstall string
+	if v, ok := d.GetOk("preinstall"); ok {
+		base64PreInstall = common.InstallScriptEncode(v.(string))
+	}
+	if v, ok := d.GetOk("postinstall"); ok {
+		base64PostInstall = common.InstallScriptEncode(v.(string))
+	}
+	userTags := resourceCCENodePoolUserTags(d)
+	if userTags == nil {
+		userTags = []tags.ResourceTag{}
+	}
+
+	updateOpts := nodePoolUpdateOpts{
 		Kind:       "NodePool",
 		ApiVersion: "v3",
 		Metadata: nodepools.UpdateMetaData{
 			Name: d.Get("name").(string),
 		},
-		Spec: nodepools.UpdateSpec{
+		Spec: nodePoolUpdateSpec{
 			Type:             "vm",
 			InitialNodeCount: d.Get("initial_node_count").(int),
 			Autoscaling: nodepools.AutoscalingSpec{
@@ -471,32 +523,384 @@ func resourceCCENodePoolV3Update(ctx context.Context, d *schema.ResourceData, me
 				ScaleDownCooldownTime: d.Get("scale_down_cooldown_time").(int),
 				Priority:              d.Get("priority").(int),
 			},
-			NodeTemplate: nodepools.UpdateNodeTemplate{
-				K8sTags: resourceCCENodeK8sTags(d),
-				Taints:  resourceCCENodeTaints(d),
+			NodeTemplate: nodePoolUpdateNodeTemplate{
+				K8sTags:  resourceCCENodeK8sTags(d),
+				Taints:   resourceCCENodeTaints(d),
+				UserTags: userTags,
+				ExtendParam: nodePoolExtendParam{
+					PreInstall:  base64PreInstall,
+					PostInstall: base64PostInstall,
+				},
 			},
 		},
 	}
-	clusterId := d.Get("cluster_id").(string)
-	_, err = nodepools.Update(nodePoolClient, clusterId, d.Id(), updateOpts).Extract()
+	_, err = nodepools.Update(nodePoolClient, clusterId, poolId, updateOpts).Extract()
 	if err != nil {
 		return fmterr.Errorf("error updating Open Telekom Cloud CCE Node Pool: %w", err)
 	}
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Synchronizing", "Synchronized"},
-		Target:     []string{""},
-		Refresh:    waitForCceNodePoolActive(nodePoolClient, clusterId, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      15 * time.Second,
-		MinTimeout: 5 * time.Second,
-	}
-	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+	if err := waitForCCENodePoolSynchronized(ctx, nodePoolClient, clusterId, poolId, d.Timeout(schema.TimeoutUpdate)); err != nil {
 		return fmterr.Errorf("error waiting for Open Telekom Cloud CCE Node Pool to update: %w", err)
 	}
 
 	return resourceCCENodePoolV3Read(ctx, d, meta)
 }
 
+// nodePoolUpdateOpts is the node pool update request including node template fields missing in the
+// `nodepools.UpdateOpts`. Template fields are sent even if empty, so removed tags, taints and scripts
+// are removed from the pool as well.
+type nodePoolUpdateOpts struct {
+	Kind       string                   `json:"kind" required:"true"`
+	ApiVersion string                   `json:"apiversion" required:"true"`
+	Metadata   nodepools.UpdateMetaData `json:"metadata" required:"true"`
+	Spec       nodePoolUpdateSpec       `json:"spec"`
+}
+
+type nodePoolUpdateSpec struct {
+	Type             string                     `json:"type,omitempty"`
+	NodeTemplate     nodePoolUpdateNodeTemplate `json:"nodeTemplate"`
+	InitialNodeCount int                        `json:"initialNodeCount"`
+	Autoscaling      nodepools.AutoscalingSpec  `json:"autoscaling"`
+}
+
+type nodePoolUpdateNodeTemplate struct {
+	K8sTags     map[string]string   `json:"k8sTags"`
+	Taints      []nodes.TaintSpec   `json:"taints"`
+	UserTags    []tags.ResourceTag  `json:"userTags"`
+	ExtendParam nodePoolExtendParam `json:"extendParam"`
+}
+
+type nodePoolExtendParam struct {
+	PreInstall  string `json:"alpha.cce/preInstall"`
+	PostInstall string `json:"alpha.cce/postInstall"`
+}
+
+func (opts nodePoolUpdateOpts) ToNodePoolUpdateMap() (map[string]interface{}, error) {
+	return golangsdk.BuildRequestBody(opts, "")
+}
+
+// validateCCENodePoolRollingUpdate forces the pool replacement on node template changes
+// unless the `rolling_update` is configured. With `rolling_update`, the nodes replacement changing
+// the pool ID is shown in the plan by `status` known only after the apply.
+func validateCCENodePoolRollingUpdate(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	rollingUpdate := d.Get("rolling_update").([]interface{})
+	if len(rollingUpdate) == 0 || rollingUpdate[0] == nil {
+		if d.Id() == "" {
+			return nil
+		}
+		for _, key := range nodePoolReplaceFields {
+			if !d.HasChange(key) {
+				continue
+			}
+			if err := d.ForceNew(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	rollingOpts := rollingUpdate[0].(map[string]interface{})
+	if rollingOpts["max_surge"].(int) == 0 && rollingOpts["max_unavailable"].(int) == 0 {
+		return fmt.Errorf("`max_surge` and `max_unavailable` of `rolling_update` can't be both 0")
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	for _, field := range nodePoolReplaceFields {
+		if d.HasChange(field) {
+			return d.SetNewComputed("status")
+		}
+	}
+	return nil
+}
+
+// resourceCCENodePoolV3RollingUpdate replaces the node pool with the new one created using the current
+// configuration. The new pool is scaled up and the old one is scaled down step by step, keeping the number of
+// nodes of both pools within `max_surge` over and `max_unavailable` below the `initial_node_count`.
+// Returns ID of the new pool once the nodes are replaced, the old pool is deleted afterwards.
+// If the nodes are not replaced, the old pool is restored and the new pool is deleted.
+func resourceCCENodePoolV3RollingUpdate(ctx context.Context, d *schema.ResourceData, client *golangsdk.ServiceClient) (string, error) {
+	clusterId := d.Get("cluster_id").(string)
+	maxSurge := d.Get("rolling_update.0.max_surge").(int)
+	maxUnavailable := d.Get("rolling_update.0.max_unavailable").(int)
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	oldPool, err := nodepools.Get(client, clusterId, d.Id()).Extract()
+	if err != nil {
+		return "", fmt.Errorf("error retrieving node pool: %w", err)
+	}
+	steps, err := nodePoolRollingUpdateSteps(oldPool.Spec.InitialNodeCount, d.Get("initial_node_count").(int), maxSurge, maxUnavailable)
+	if err != nil {
+		return "", err
+	}
+
+	// pool is renamed to the configured name by the update following the replacement
+	createOpts := resourceCCENodePoolV3CreateOpts(d)
+	createOpts.Metadata.Name = fmt.Sprintf("%s-%s", createOpts.Metadata.Name, strconv.FormatInt(time.Now().Unix(), 36))
+	createOpts.Spec.InitialNodeCount = 0
+	createOpts.Spec.Autoscaling = nodepools.AutoscalingSpec{}
+	log.Printf("[DEBUG] Create Options: %#v", createOpts)
+	newPool, err := nodepools.Create(client, clusterId, createOpts).Extract()
+	if err != nil {
+		return "", fmt.Errorf("error creating replacement node pool: %w", err)
+	}
+	newPoolId := newPool.Metadata.Id
+	log.Printf("[DEBUG] Replacing CCE node pool %s with %s", oldPool.Metadata.Id, newPoolId)
+
+	replacement := &nodePoolReplacement{
+		oldPoolId:      oldPool.Metadata.Id,
+		oldCount:       oldPool.Spec.InitialNodeCount,
+		oldAutoscaling: oldPool.Spec.Autoscaling,
+		newPoolId:      newPoolId,
+		scale: func(poolId string, count int, autoscaling nodepools.AutoscalingSpec) error {
+			return scaleCCENodePool(ctx, client, clusterId, poolId, count, autoscaling, timeout)
+		},
+		waitActive: func(poolId string) error {
+			return waitForCCENodePoolNodesActive(ctx, client, clusterId, poolId, timeout)
+		},
+		delete: func(poolId string) error {
+			return nodepools.Delete(client, clusterId, poolId).ExtractErr()
+		},
+	}
+	if err := waitForCCENodePoolSynchronized(ctx, client, clusterId, newPoolId, timeout); err != nil {
+		return "", replacement.rollback(fmt.Errorf("error waiting for replacement node pool %s to be created: %w", newPoolId, err))
+	}
+	if err := replacement.run(steps); err != nil {
+		return "", err
+	}
+
+	if err := nodepools.Delete(client, clusterId, oldPool.Metadata.Id).ExtractErr(); err != nil {
+		return newPoolId, fmt.Errorf("error deleting replaced node pool %s: %w", oldPool.Metadata.Id, err)
+	}
+	stateConf := &waiter.Config{
+		Description: fmt.Sprintf("CCE node pool %s", oldPool.Metadata.Id),
+		Pending:     []string{"Deleting"},
+		Target:      []string{waiter.Deleted},
+		Error:       []string{"Error"},
+		Refresh:     cceNodePoolRefreshFunc(client, clusterId, oldPool.Metadata.Id),
+		Timeout:     timeout,
+		Delay:       15 * time.Second,
+		MinInterval: 10 * time.Second,
+	}
+	if _, err := stateConf.Wait(ctx); err != nil {
+		return newPoolId, fmt.Errorf("error waiting for replaced node pool %s to be deleted: %w", oldPool.Metadata.Id, err)
+	}
+	return newPoolId, nil
+}
+
+// nodePoolReplacement scales the replacement pool up and the replaced one down
+type nodePoolReplacement struct {
+	oldPoolId      string
+	oldCount       int
+	oldAutoscaling nodepools.AutoscalingSpec
+	newPoolId      string
+	// oldScaled is set once the replaced pool is scaled
+	oldScaled bool
+
+	// scale sets the number of nodes and the autoscaling of the pool
+	scale func(poolId string, count int, autoscaling nodepools.AutoscalingSpec) error
+	// waitActive waits for the nodes of the pool to be active
+	waitActive func(poolId string) error
+	delete     func(poolId string) error
+}
+
+// run applies the steps with the autoscaling of both pools disabled, so it doesn't interfere with the replacement.
+// If any step fails, the replacement is rolled back.
+func (r *nodePoolReplacement) run(steps []nodePoolScaleStep) error {
+	for _, step := range steps {
+		if !step.replacement {
+			r.oldScaled = true
+			if err := r.scale(r.oldPoolId, step.count, nodepools.AutoscalingSpec{}); err != nil {
+				return r.rollback(fmt.Errorf("error scaling down node pool %s: %w", r.oldPoolId, err))
+			}
+			continue
+		}
+		if err := r.scale(r.newPoolId, step.count, nodepools.AutoscalingSpec{}); err != nil {
+			return r.rollback(fmt.Errorf("error scaling up replacement node pool %s: %w", r.newPoolId, err))
+		}
+		if err := r.waitActive(r.newPoolId); err != nil {
+			return r.rollback(fmt.Errorf("error waiting for nodes of replacement node pool %s: %w", r.newPoolId, err))
+		}
+	}
+	return nil
+}
+
+// rollback scales the replaced pool back to its original size and autoscaling before the replacement
+// pool is deleted, so the cluster capacity is not reduced. If the replaced pool can't be restored,
+// both pools are kept.
+func (r *nodePoolReplacement) rollback(err error) error {
+	if r.oldScaled {
+		if restoreErr := r.scale(r.oldPoolId, r.oldCount, r.oldAutoscaling); restoreErr != nil {
+			return fmt.Errorf("%w\nerror restoring node pool %s, replacement node pool %s is kept: %s", err, r.oldPoolId, r.newPoolId, restoreErr)
+		}
+		if waitErr := r.waitActive(r.oldPoolId); waitErr != nil {
+			return fmt.Errorf("%w\nerror waiting for nodes of restored node pool %s, replacement node pool %s is kept: %s", err, r.oldPoolId, r.newPoolId, waitErr)
+		}
+	}
+	if deleteErr := r.delete(r.newPoolId); deleteErr != nil {
+		return fmt.Errorf("%w\nerror deleting replacement node pool %s: %s", err, r.newPoolId, deleteErr)
+	}
+	return err
+}
+
+// nodePoolScaleStep sets the number of nodes of the replacement pool or of the replaced one
+type nodePoolScaleStep struct {
+	replacement bool
+	count       int
+}
+
+// nodePoolRollingUpdateSteps returns the steps replacing `oldCount` nodes of the old pool with `target` nodes
+// of the new one. New nodes are added before the old ones are removed, the total number of nodes is kept
+// within `maxSurge` over and `maxUnavailable` below the `target`. Excess nodes of the old pool are removed first.
+func nodePoolRollingUpdateSteps(oldCount, target, maxSurge, maxUnavailable int) ([]nodePoolScaleStep, error) {
+	var steps []nodePoolScaleStep
+	newCount := 0
+	for newCount < target || oldCount > 0 {
+		scaled := false
+		if count := minInt(target, target+maxSurge-oldCount); count > newCount {
+			steps = append(steps, nodePoolScaleStep{replacement: true, count: count})
+			newCount = count
+			scaled = true
+		}
+		if count := maxInt(0, minInt(oldCount, target-maxUnavailable-newCount)); count < oldCount {
+			steps = append(steps, nodePoolScaleStep{count: count})
+			oldCount = count
+			scaled = true
+		}
+		if !scaled {
+			return nil, fmt.Errorf("`max_surge` and `max_unavailable` of `rolling_update` can't be both 0")
+		}
+	}
+	return steps, nil
+}
+
+// scaleCCENodePool sets the number of nodes and the autoscaling of the pool keeping the rest of its spec
+func scaleCCENodePool(ctx context.Context, client *golangsdk.ServiceClient, clusterId, poolId string, count int, autoscaling nodepools.AutoscalingSpec, timeout time.Duration) error {
+	pool, err := nodepools.Get(client, clusterId, poolId).Extract()
+	if err != nil {
+		return err
+	}
+	template := pool.Spec.NodeTemplate
+	updateOpts := nodePoolUpdateOpts{
+		Kind:       "NodePool",
+		ApiVersion: "v3",
+		Metadata: nodepools.UpdateMetaData{
+			Name: pool.Metadata.Name,
+		},
+		Spec: nodePoolUpdateSpec{
+			Type:             pool.Spec.Type,
+			InitialNodeCount: count,
+			Autoscaling:      autoscaling,
+			NodeTemplate: nodePoolUpdateNodeTemplate{
+				K8sTags:  template.K8sTags,
+				Taints:   template.Taints,
+				UserTags: template.UserTags,
+				ExtendParam: nodePoolExtendParam{
+					PreInstall:  template.ExtendParam.PreInstall,
+					PostInstall: template.ExtendParam.PostInstall,
+				},
+			},
+		},
+	}
+	log.Printf("[DEBUG] Scaling CCE node pool %s to %d nodes", poolId, count)
+	if _, err := nodepools.Update(client, clusterId, poolId, updateOpts).Extract(); err != nil {
+		return err
+	}
+	if err := waitForCCENodePoolSynchronized(ctx, client, clusterId, poolId, timeout); err != nil {
+		return err
+	}
+
+	stateConf := &waiter.Config{
+		Description: fmt.Sprintf("nodes of CCE node pool %s", poolId),
+		Target:      []string{"Scaled"},
+		Refresh:     cceNodePoolNodeCountRefreshFunc(client, clusterId, poolId, count),
+		Timeout:     timeout,
+		Delay:       15 * time.Second,
+		MinInterval: 10 * time.Second,
+	}
+	_, err = stateConf.Wait(ctx)
+	return err
+}
+
+// waitForCCENodePoolNodesActive waits for each node of the pool to be active
+func waitForCCENodePoolNodesActive(ctx context.Context, client *golangsdk.ServiceClient, clusterId, poolId string, timeout time.Duration) error {
+	poolNodes, err := listCCENodePoolNodes(client, clusterId, poolId)
+	if err != nil {
+		return err
+	}
+	for _, node := range poolNodes {
+		stateConf := &waiter.Config{
+			Description: fmt.Sprintf("CCE node %s", node.Metadata.Id),
+			Pending:     []string{"Build", "Installing"},
+			Target:      []string{"Active"},
+			Error:       []string{"Error", "Abnormal"},
+			Refresh:     cceNodeRefreshFunc(client, clusterId, node.Metadata.Id),
+			Timeout:     timeout,
+			MinInterval: 10 * time.Second,
+		}
+		if _, err := stateConf.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func waitForCCENodePoolSynchronized(ctx context.Context, client *golangsdk.ServiceClient, clusterId, poolId string, timeout time.Duration) error {
+	stateConf := &waiter.Config{
+		Description: fmt.Sprintf("CCE node pool %s", poolId),
+		Pending:     []string{"Synchronizing", "Synchronized"},
+		Target:      []string{""},
+		Error:       []string{"Error"},
+		Refresh:     cceNodePoolRefreshFunc(client, clusterId, poolId),
+		Timeout:     timeout,
+		Delay:       15 * time.Second,
+		MinInterval: 5 * time.Second,
+	}
+	_, err := stateConf.Wait(ctx)
+	return err
+}
+
+// listCCENodePoolNodes returns nodes of the cluster belonging to the pool
+func listCCENodePoolNodes(client *golangsdk.ServiceClient, clusterId, poolId string) ([]nodes.Nodes, error) {
+	clusterNodes, err := nodes.List(client, clusterId, nodes.ListOpts{})
+	if err != nil {
+		return nil, err
+	}
+	var poolNodes []nodes.Nodes
+	for _, node := range clusterNodes {
+		if node.Metadata.Annotations[nodePoolIDAnnotation] == poolId {
+			poolNodes = append(poolNodes, node)
+		}
+	}
+	return poolNodes, nil
+}
+
+func cceNodePoolNodeCountRefreshFunc(client *golangsdk.ServiceClient, clusterId, poolId string, count int) waiter.RefreshFunc {
+	return func() (interface{}, string, error) {
+		poolNodes, err := listCCENodePoolNodes(client, clusterId, poolId)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(poolNodes) != count {
+			return poolNodes, "Scaling", nil
+		}
+		return poolNodes, "Scaled", nil
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 func resourceCCENodePoolV3Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*cfg.Config)
 	client, err := config.CceV3Client(config.GetRegion(d))
@@ -508,16 +912,18 @@ func resourceCCENodePoolV3Delete(ctx context.Context, d *schema.ResourceData, me
 	if err := nodepools.Delete(client, clusterId, d.Id()).ExtractErr(); err != nil {
 		return fmterr.Errorf("error deleting Open Telekom Cloud CCE Node Pool: %w", err)
 	}
-	stateConf := &resource.StateChangeConf{
-		Pending:      []string{"Deleting"},
-		Target:       []string{"Deleted"},
-		Refresh:      waitForCceNodePoolDelete(client, clusterId, d.Id()),
-		Timeout:      d.Timeout(schema.TimeoutDelete),
-		Delay:        60 * time.Second,
-		PollInterval: 20 * time.Second,
+	stateConf := &waiter.Config{
+		Description: fmt.Sprintf("CCE node pool %s", d.Id()),
+		Pending:     []string{"Deleting"},
+		Target:      []string{waiter.Deleted},
+		Error:       []string{"Error"},
+		Refresh:     cceNodePoolRefreshFunc(client, clusterId, d.Id()),
+		Timeout:     d.Timeout(schema.TimeoutDelete),
+		Delay:       60 * time.Second,
+		MinInterval: 20 * time.Second,
 	}
 
-	_, err = stateConf.WaitForStateContext(ctx)
+	_, err = stateConf.Wait(ctx)
 	if err != nil {
 		return fmterr.Errorf("error waiting for Open Telekom Cloud CCE Node Pool to be deleted: %w", err)
 	}
@@ -526,7 +932,7 @@ func resourceCCENodePoolV3Delete(ctx context.Context, d *schema.ResourceData, me
 	return nil
 }
 
-func waitForCceNodePoolActive(cceClient *golangsdk.ServiceClient, clusterId, nodePoolId string) resource.StateRefreshFunc {
+func cceNodePoolRefreshFunc(cceClient *golangsdk.ServiceClient, clusterId, nodePoolId string) waiter.RefreshFunc {
 	return func() (interface{}, string, error) {
 		n, err := nodepools.Get(cceClient, clusterId, nodePoolId).Extract()
 		if err != nil {
@@ -536,25 +942,6 @@ func waitForCceNodePoolActive(cceClient *golangsdk.ServiceClient, clusterId, nod
 	}
 }
 
-func waitForCceNodePoolDelete(cceClient *golangsdk.ServiceClient, clusterId, nodePoolId string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		log.Printf("[DEBUG] Attempting to delete Open Telekom Cloud CCE Node Pool %s.\n", nodePoolId)
-
-		r, err := nodepools.Get(cceClient, clusterId, nodePoolId).Extract()
-
-		if err != nil {
-			if _, ok := err.(golangsdk.ErrDefault404); ok {
-				log.Printf("[DEBUG] Successfully deleted Open Telekom Cloud CCE Node Pool %s", nodePoolId)
-				return r, "Deleted", nil
-			}
-			return r, "Deleting", err
-		}
-
-		log.Printf("[DEBUG] Open Telekom Cloud Node Pool %s still available.\n", nodePoolId)
-		return r, r.Status.Phase, nil
-	}
-}
-
 func resourceCCENodePoolV3Import(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	parts := strings.SplitN(d.Id(), "/", 2)
 	if len(parts) != 2 {
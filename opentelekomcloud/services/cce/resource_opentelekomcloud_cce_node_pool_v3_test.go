@@ -0,0 +1,186 @@
+package cce
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/nodepools"
+	th "github.com/opentelekomcloud/gophertelekomcloud/testhelper"
+)
+
+// formatSteps formats the steps as `+N` for the replacement pool and `-N` for the replaced one
+func formatSteps(steps []nodePoolScaleStep) string {
+	formatted := make([]string, len(steps))
+	for i, step := range steps {
+		sign := "-"
+		if step.replacement {
+			sign = "+"
+		}
+		formatted[i] = fmt.Sprintf("%s%d", sign, step.count)
+	}
+	return strings.Join(formatted, " ")
+}
+
+func TestNodePoolRollingUpdateSteps(t *testing.T) {
+	cases := []struct {
+		name           string
+		oldCount       int
+		target         int
+		maxSurge       int
+		maxUnavailable int
+		expected       string
+	}{
+		{"one by one", 3, 3, 1, 0, "+1 -2 +2 -1 +3 -0"},
+		{"unavailable only", 3, 3, 0, 1, "-2 +1 -1 +2 -0 +3"},
+		{"surge and unavailable", 3, 3, 2, 1, "+2 -0 +3"},
+		{"surge over target", 3, 3, 10, 0, "+3 -0"},
+		{"unavailable over target", 3, 3, 0, 10, "-0 +3"},
+		{"old pool larger", 5, 2, 1, 0, "-2 +1 -1 +2 -0"},
+		{"old pool smaller", 1, 3, 1, 0, "+3 -0"},
+		{"old pool empty", 0, 2, 1, 0, "+2"},
+		{"old pool empty without surge", 0, 2, 0, 0, "+2"},
+		{"target empty", 2, 0, 1, 0, "-0"},
+		{"both empty", 0, 0, 1, 0, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			steps, err := nodePoolRollingUpdateSteps(c.oldCount, c.target, c.maxSurge, c.maxUnavailable)
+			th.AssertNoErr(t, err)
+			th.AssertEquals(t, c.expected, formatSteps(steps))
+
+			// nodes are added over the target only within the surge, and removed below it only within unavailable
+			oldCount, newCount := c.oldCount, 0
+			minTotal := minInt(c.oldCount, c.target) - c.maxUnavailable
+			for i, step := range steps {
+				if step.replacement {
+					newCount = step.count
+				} else {
+					oldCount = step.count
+				}
+				total := oldCount + newCount
+				if step.replacement && total > maxInt(c.oldCount, c.target+c.maxSurge) || total < minTotal {
+					t.Fatalf("%d nodes after step %d", total, i+1)
+				}
+			}
+			th.AssertEquals(t, 0, oldCount)
+			th.AssertEquals(t, c.target, newCount)
+		})
+	}
+
+	_, err := nodePoolRollingUpdateSteps(3, 3, 0, 0)
+	th.AssertEquals(t, "`max_surge` and `max_unavailable` of `rolling_update` can't be both 0", err.Error())
+}
+
+// testReplacement returns the replacement of the pool `old` with 3 nodes by the pool `new`
+// recording calls, the call matching `failing` returns an error. Repeated call is matched as `call#N`.
+func testReplacement(calls *[]string, failing ...string) *nodePoolReplacement {
+	counts := make(map[string]int)
+	call := func(format string, args ...interface{}) error {
+		c := fmt.Sprintf(format, args...)
+		*calls = append(*calls, c)
+		counts[c]++
+		for _, f := range failing {
+			if c == f || fmt.Sprintf("%s#%d", c, counts[c]) == f {
+				return fmt.Errorf("%s failed", c)
+			}
+		}
+		return nil
+	}
+	return &nodePoolReplacement{
+		oldPoolId:      "old",
+		oldCount:       3,
+		oldAutoscaling: nodepools.AutoscalingSpec{Enable: true, MinNodeCount: 1, MaxNodeCount: 5},
+		newPoolId:      "new",
+		scale: func(poolId string, count int, autoscaling nodepools.AutoscalingSpec) error {
+			if autoscaling.Enable {
+				return call("scale %s %d autoscaling %d-%d", poolId, count, autoscaling.MinNodeCount, autoscaling.MaxNodeCount)
+			}
+			return call("scale %s %d", poolId, count)
+		},
+		waitActive: func(poolId string) error {
+			return call("wait %s", poolId)
+		},
+		delete: func(poolId string) error {
+			return call("delete %s", poolId)
+		},
+	}
+}
+
+func TestNodePoolReplacement(t *testing.T) {
+	steps, err := nodePoolRollingUpdateSteps(3, 3, 1, 0)
+	th.AssertNoErr(t, err)
+
+	cases := []struct {
+		name     string
+		failing  []string
+		expected []string
+		err      string
+	}{
+		{
+			name: "replaced",
+			expected: []string{
+				"scale new 1", "wait new", "scale old 2", "scale new 2", "wait new", "scale old 1",
+				"scale new 3", "wait new", "scale old 0",
+			},
+		},
+		{
+			name:     "replacement not scaled",
+			failing:  []string{"scale new 1"},
+			expected: []string{"scale new 1", "delete new"},
+			err:      "error scaling up replacement node pool new: scale new 1 failed",
+		},
+		{
+			name:    "old pool restored",
+			failing: []string{"wait new#2"},
+			expected: []string{
+				"scale new 1", "wait new", "scale old 2", "scale new 2", "wait new",
+				"scale old 3 autoscaling 1-5", "wait old", "delete new",
+			},
+			err: "error waiting for nodes of replacement node pool new: wait new failed",
+		},
+		{
+			name:    "old pool scale down failed",
+			failing: []string{"scale old 1"},
+			expected: []string{
+				"scale new 1", "wait new", "scale old 2", "scale new 2", "wait new", "scale old 1",
+				"scale old 3 autoscaling 1-5", "wait old", "delete new",
+			},
+			err: "error scaling down node pool old: scale old 1 failed",
+		},
+		{
+			name:    "old pool not restored",
+			failing: []string{"scale new 2", "scale old 3 autoscaling 1-5"},
+			expected: []string{
+				"scale new 1", "wait new", "scale old 2", "scale new 2", "scale old 3 autoscaling 1-5",
+			},
+			err: "error scaling up replacement node pool new: scale new 2 failed\n" +
+				"error restoring node pool old, replacement node pool new is kept: scale old 3 autoscaling 1-5 failed",
+		},
+		{
+			name:    "replacement not deleted",
+			failing: []string{"scale new 2", "delete new"},
+			expected: []string{
+				"scale new 1", "wait new", "scale old 2", "scale new 2",
+				"scale old 3 autoscaling 1-5", "wait old", "delete new",
+			},
+			err: "error scaling up replacement node pool new: scale new 2 failed\n" +
+				"error deleting replacement node pool new: delete new failed",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var calls []string
+			err := testReplacement(&calls, c.failing...).run(steps)
+			th.AssertDeepEquals(t, c.expected, calls)
+			if c.err == "" {
+				th.AssertNoErr(t, err)
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error: %s", c.err)
+			}
+			th.AssertEquals(t, c.err, err.Error())
+		})
+	}
+}
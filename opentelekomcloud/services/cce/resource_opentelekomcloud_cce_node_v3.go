@@ -8,11 +8,9 @@ import (
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/opentelekomcloud/gophertelekomcloud"
-	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/clusters"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/nodes"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/common/tags"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/extensions/floatingips"
@@ -22,6 +20,7 @@ import (
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common"
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/cfg"
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/fmterr"
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/waiter"
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/services/vpc"
 )
 
@@ -42,9 +41,11 @@ func ResourceCCENodeV3() *schema.Resource {
 		},
 
 		CustomizeDiff: common.MultipleCustomizeDiffs(
+			common.SetTagsAllDiff,
 			common.ValidateVolumeType("root_volume.*.volumetype"),
 			common.ValidateVolumeType("data_volumes.*.volumetype"),
 			common.ValidateSubnet("subnet_id"),
+			common.ValidateFlavor("availability_zone", "flavor_id"),
 		),
 
 		Schema: map[string]*schema.Schema{
@@ -266,6 +267,7 @@ func ResourceCCENodeV3() *schema.Resource {
 				ConflictsWith: []string{"labels"},
 				Optional:      true,
 			},
+			"tags_all": common.TagsAllSchema(),
 			"status": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -344,7 +346,7 @@ func resourceCCENodeAnnotationsV2(d *schema.ResourceData) map[string]string {
 }
 
 func resourceCCENodeTags(d *schema.ResourceData) []tags.ResourceTag {
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := d.Get("tags_all").(map[string]interface{})
 	return common.ExpandResourceTags(tagRaw)
 }
 
@@ -488,14 +490,9 @@ func resourceCCENodeV3Create(ctx context.Context, d *schema.ResourceData, meta i
 	}
 
 	clusterId := d.Get("cluster_id").(string)
-	stateCluster := &resource.StateChangeConf{
-		Target:     []string{"Available"},
-		Refresh:    waitForClusterAvailable(nodeClient, clusterId),
-		Timeout:    15 * time.Minute,
-		Delay:      15 * time.Second,
-		MinTimeout: 3 * time.Second,
+	if err := waitForCCEClusterAvailable(ctx, nodeClient, clusterId, 15*time.Minute); err != nil {
+		log.Printf("[WARN] Cluster unavailable: %s", err)
 	}
-	_, err = stateCluster.WaitForStateContext(ctx)
 
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 	s, err := nodes.Create(nodeClient, clusterId, createOpts).Extract()
@@ -531,15 +528,17 @@ func resourceCCENodeV3Create(ctx context.Context, d *schema.ResourceData, meta i
 	}
 
 	log.Printf("[DEBUG] Waiting for CCE Node (%s) to become available", s.Metadata.Name)
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Build", "Installing"},
-		Target:     []string{"Active"},
-		Refresh:    waitForCceNodeActive(nodeClient, clusterId, nodeId),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-	_, err = stateConf.WaitForStateContext(ctx)
+	stateConf := &waiter.Config{
+		Description: fmt.Sprintf("CCE node %s", nodeId),
+		Pending:     []string{"Build", "Installing"},
+		Target:      []string{"Active"},
+		Error:       []string{"Error", "Abnormal"},
+		Refresh:     cceNodeRefreshFunc(nodeClient, clusterId, nodeId),
+		Timeout:     d.Timeout(schema.TimeoutCreate),
+		Delay:       5 * time.Second,
+		MinInterval: 3 * time.Second,
+	}
+	_, err = stateConf.Wait(ctx)
 	if err != nil {
 		return fmterr.Errorf("error creating OpenTelekomCloud CCE Node: %s", err)
 	}
@@ -637,7 +636,7 @@ func resourceCCENodeV3Read(_ context.Context, d *schema.ResourceData, meta inter
 	tagMap := common.TagsToMap(resourceTags)
 	// ignore "CCE-Dynamic-Provisioning-Node"
 	delete(tagMap, "CCE-Dynamic-Provisioning-Node")
-	if err := d.Set("tags", tagMap); err != nil {
+	if err := common.SetResourceTags(d, meta, tagMap); err != nil {
 		return fmterr.Errorf("error saving tags of CCE node: %s", err)
 	}
 
@@ -664,7 +663,7 @@ func resourceCCENodeV3Update(ctx context.Context, d *schema.ResourceData, meta i
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if d.HasChange("tags_all") {
 		computeClient, err := config.ComputeV1Client(config.GetRegion(d))
 		if err != nil {
 			return fmterr.Errorf("error creating OpenTelekomCloud compute client: %s", err)
@@ -743,16 +742,18 @@ func resourceCCENodeV3Delete(ctx context.Context, d *schema.ResourceData, meta i
 	if err != nil {
 		return fmterr.Errorf("error deleting OpenTelekomCloud CCE Cluster: %s", err)
 	}
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Deleting"},
-		Target:     []string{"Deleted"},
-		Refresh:    waitForCceNodeDelete(nodeClient, clusterId, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
+	stateConf := &waiter.Config{
+		Description: fmt.Sprintf("CCE node %s", d.Id()),
+		Pending:     []string{"Deleting"},
+		Target:      []string{waiter.Deleted},
+		Error:       []string{"Error"},
+		Refresh:     cceNodeRefreshFunc(nodeClient, clusterId, d.Id()),
+		Timeout:     d.Timeout(schema.TimeoutDelete),
+		Delay:       5 * time.Second,
+		MinInterval: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForStateContext(ctx)
+	_, err = stateConf.Wait(ctx)
 	if err != nil {
 		return fmterr.Errorf("error deleting OpenTelekomCloud CCE Node: %s", err)
 	}
@@ -912,60 +913,19 @@ func checkCCENodeV3PublicIpParams(d *schema.ResourceData) {
 	}
 }
 
-func waitForCceNodeActive(cceClient *golangsdk.ServiceClient, clusterId, nodeId string) resource.StateRefreshFunc {
+func cceNodeRefreshFunc(cceClient *golangsdk.ServiceClient, clusterId, nodeId string) waiter.RefreshFunc {
 	return func() (interface{}, string, error) {
 		n, err := nodes.Get(cceClient, clusterId, nodeId).Extract()
 		if err != nil {
 			return nil, "", err
 		}
-
-		return n, n.Status.Phase, nil
-	}
-}
-
-func waitForCceNodeDelete(cceClient *golangsdk.ServiceClient, clusterId, nodeId string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		log.Printf("[DEBUG] Attempting to delete OpenTelekomCloud CCE Node %s.\n", nodeId)
-
-		r, err := nodes.Get(cceClient, clusterId, nodeId).Extract()
-
-		if err != nil {
-			if _, ok := err.(golangsdk.ErrDefault404); ok {
-				log.Printf("[DEBUG] Successfully deleted OpenTelekomCloud CCE Node %s", nodeId)
-				return r, "Deleted", nil
-			}
-			return r, "Deleting", err
-		}
-
-		log.Printf("[DEBUG] OpenTelekomCloud CCE Node %s still available.\n", nodeId)
-		return r, r.Status.Phase, nil
-	}
-}
-
-func waitForClusterAvailable(cceClient *golangsdk.ServiceClient, clusterId string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		log.Printf("[INFO] Waiting for OpenTelekomCloud Cluster to be available %s.\n", clusterId)
-		n, err := clusters.Get(cceClient, clusterId).Extract()
-
-		if err != nil {
-			return nil, "", err
-		}
-
 		return n, n.Status.Phase, nil
 	}
 }
 
 func recursiveCreate(ctx context.Context, cceClient *golangsdk.ServiceClient, opts nodes.CreateOptsBuilder, ClusterID string, errCode int) (*nodes.Nodes, string) {
 	if errCode == 403 {
-		stateCluster := &resource.StateChangeConf{
-			Target:     []string{"Available"},
-			Refresh:    waitForClusterAvailable(cceClient, ClusterID),
-			Timeout:    15 * time.Minute,
-			Delay:      15 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-		_, stateErr := stateCluster.WaitForStateContext(ctx)
-		if stateErr != nil {
+		if stateErr := waitForCCEClusterAvailable(ctx, cceClient, ClusterID, 15*time.Minute); stateErr != nil {
 			log.Printf("[INFO] Cluster Unavailable %s.\n", stateErr)
 		}
 		s, err := nodes.Create(cceClient, ClusterID, opts).Extract()
@@ -27,6 +27,10 @@ func ResourceAlarmRule() *schema.Resource {
 		UpdateContext: resourceAlarmRuleUpdate,
 		DeleteContext: resourceAlarmRuleDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -3,12 +3,10 @@ package css
 import (
 	"context"
 	"fmt"
-	"math"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/css/v1/clusters"
@@ -16,6 +14,7 @@ import (
 
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/cfg"
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/fmterr"
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/waiter"
 )
 
 func ResourceCssClusterV1() *schema.Resource {
@@ -25,6 +24,10 @@ func ResourceCssClusterV1() *schema.Resource {
 		UpdateContext: resourceCssClusterV1Update,
 		DeleteContext: resourceCssClusterV1Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(30 * time.Minute),
@@ -252,9 +255,17 @@ func resourceCssClusterV1Create(ctx context.Context, d *schema.ResourceData, met
 		return fmterr.Errorf("error creating CSS cluster: %s", err)
 	}
 
-	secondsWait := int(math.Round(d.Timeout(schema.TimeoutCreate).Seconds()))
-	err = clusters.WaitForClusterOperationSucces(client, created.ID, secondsWait)
-	if err != nil {
+	stateConf := &waiter.Config{
+		Description: fmt.Sprintf("CSS cluster %s", created.ID),
+		Pending:     []string{clusterStatusInProgress},
+		Target:      []string{clusterStatusAvailable},
+		Error:       []string{clusterStatusFailed},
+		Refresh:     cssClusterStatusRefreshFunc(client, created.ID),
+		Timeout:     d.Timeout(schema.TimeoutCreate),
+		Delay:       30 * time.Second,
+		MinInterval: 10 * time.Second,
+	}
+	if err := waitForCssCluster(ctx, stateConf); err != nil {
 		return fmterr.Errorf("error waiting for CSS cluster to be running: %s", err)
 	}
 
@@ -270,10 +281,23 @@ func resourceCssClusterV1Read(_ context.Context, d *schema.ResourceData, meta in
 		return fmterr.Errorf("error creating CSS v1 client: %s", err)
 	}
 
-	cluster, err := clusters.Get(client, d.Id()).Extract()
+	result := clusters.Get(client, d.Id())
+	cluster, err := result.Extract()
 	if err != nil {
 		return fmterr.Errorf("error reading cluster value: %s", err)
 	}
+	// volumes of the nodes are missing in the SDK cluster struct
+	var volumes struct {
+		Instances []struct {
+			Volume struct {
+				Type string `json:"type"`
+				Size int    `json:"size"`
+			} `json:"volume"`
+		} `json:"instances"`
+	}
+	if err := result.ExtractInto(&volumes); err != nil {
+		return fmterr.Errorf("error reading cluster volumes: %s", err)
+	}
 
 	mErr := multierror.Append(
 		d.Set("name", cluster.Name),
@@ -285,8 +309,39 @@ func resourceCssClusterV1Read(_ context.Context, d *schema.ResourceData, meta in
 
 		d.Set("nodes", extractNodes(cluster)),
 		d.Set("datastore", extractDatastore(cluster)),
+		d.Set("expect_node_num", len(cluster.Instances)),
 	)
 
+	if len(cluster.Instances) != 0 {
+		// AZ is optional and empty by default, so it is set only when it's unknown, e.g. on import
+		az := d.Get("node_config.0.availability_zone").(string)
+		if len(d.Get("node_config").([]interface{})) == 0 {
+			az = cluster.Instances[0].AvailabilityZone
+		}
+		volume := volumes.Instances[0].Volume
+		nodeConfig := []interface{}{
+			map[string]interface{}{
+				"flavor": cluster.Instances[0].SpecCode,
+				"network_info": []interface{}{
+					map[string]interface{}{
+						"network_id":        cluster.SubnetID,
+						"security_group_id": cluster.SecurityGroupID,
+						"vpc_id":            cluster.VpcID,
+					},
+				},
+				"volume": []interface{}{
+					map[string]interface{}{
+						"size":           volume.Size,
+						"volume_type":    volume.Type,
+						"encryption_key": cluster.CmkID,
+					},
+				},
+				"availability_zone": az,
+			},
+		}
+		mErr = multierror.Append(mErr, d.Set("node_config", nodeConfig))
+	}
+
 	if err := mErr.ErrorOrNil(); err != nil {
 		return diag.FromErr(err)
 	}
@@ -338,13 +393,17 @@ func resourceCssClusterV1Update(ctx context.Context, d *schema.ResourceData, met
 		return fmterr.Errorf("error extending cluster: %s", err)
 	}
 
-	secondsWait := int(math.Round(d.Timeout(schema.TimeoutUpdate).Seconds()))
-	if err := clusters.WaitForClusterToExtend(client, d.Id(), secondsWait); err != nil {
-		state, _ := clusters.Get(client, d.Id()).Extract()
-		if state != nil {
-			return fmterr.Errorf("error waiting cluster to extend: %s\nFail reason: %+v", err, state.FailedReasons)
-		}
-		return fmterr.Errorf("error waiting cluster to extend: %s", err)
+	stateConf := &waiter.Config{
+		Description: fmt.Sprintf("CSS cluster %s", d.Id()),
+		Pending:     []string{clusterActionGrowing},
+		Target:      []string{clusterStateAvailable},
+		Refresh:     cssClusterActionRefreshFunc(client, d.Id()),
+		Timeout:     d.Timeout(schema.TimeoutUpdate),
+		Delay:       30 * time.Second,
+		MinInterval: 10 * time.Second,
+	}
+	if err := waitForCssCluster(ctx, stateConf); err != nil {
+		return fmterr.Errorf("error waiting cluster to extend: %w", err)
 	}
 
 	return resourceCssClusterV1Read(ctx, d, meta)
@@ -361,15 +420,15 @@ func resourceCssClusterV1Delete(ctx context.Context, d *schema.ResourceData, met
 		return fmterr.Errorf("error deleting cluster: %s", err)
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{},
-		Pending:    []string{clusterStateAvailable},
-		Refresh:    resourceCssClusterV1StateRefresh(client, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 10 * time.Second,
+	stateConf := &waiter.Config{
+		Description: fmt.Sprintf("CSS cluster %s", d.Id()),
+		Target:      []string{waiter.Deleted},
+		Refresh:     cssClusterStatusRefreshFunc(client, d.Id()),
+		Timeout:     d.Timeout(schema.TimeoutDelete),
+		Delay:       10 * time.Second,
+		MinInterval: 10 * time.Second,
 	}
-	_, err = stateConf.WaitForStateContext(ctx)
+	_, err = stateConf.Wait(ctx)
 	if err != nil {
 		return fmterr.Errorf("error waiting for cluster to be deleted: %s", err)
 	}
@@ -378,18 +437,48 @@ func resourceCssClusterV1Delete(ctx context.Context, d *schema.ResourceData, met
 
 const (
 	clusterStateAvailable = "AVAILABLE"
+
+	clusterStatusInProgress = "100"
+	clusterStatusAvailable  = "200"
+	clusterStatusFailed     = "303"
+
+	clusterActionGrowing = "GROWING"
 )
 
-func resourceCssClusterV1StateRefresh(client *golangsdk.ServiceClient, id string) resource.StateRefreshFunc {
+// waitForCssCluster waits for the cluster operation, adding the failure reasons reported by the cluster to the error
+func waitForCssCluster(ctx context.Context, stateConf *waiter.Config) error {
+	result, err := stateConf.Wait(ctx)
+	if err == nil {
+		return nil
+	}
+	if cluster, ok := result.(*clusters.Cluster); ok && cluster.FailedReasons != nil {
+		return fmt.Errorf("%w, fail reason: %+v", err, *cluster.FailedReasons)
+	}
+	return err
+}
+
+// cssClusterStatusRefreshFunc reports the cluster status code
+func cssClusterStatusRefreshFunc(client *golangsdk.ServiceClient, id string) waiter.RefreshFunc {
+	return func() (result interface{}, state string, err error) {
+		cluster, err := clusters.Get(client, id).Extract()
+		if err != nil {
+			return nil, "", err
+		}
+		return cluster, cluster.Status, nil
+	}
+}
+
+// cssClusterActionRefreshFunc reports the cluster action in progress, if any
+func cssClusterActionRefreshFunc(client *golangsdk.ServiceClient, id string) waiter.RefreshFunc {
 	return func() (result interface{}, state string, err error) {
 		cluster, err := clusters.Get(client, id).Extract()
 		if err != nil {
-			if _, ok := err.(golangsdk.ErrDefault404); ok {
-				return nil, "", nil
-			}
 			return nil, "", err
 		}
-		return cluster, clusterStateAvailable, nil
+		if len(cluster.Actions) == 0 {
+			return cluster, clusterStateAvailable, nil
+		}
+		return cluster, cluster.Actions[0], nil
 	}
 }
 
@@ -22,6 +22,10 @@ func ResourceCssSnapshotConfigurationV1() *schema.Resource {
 		UpdateContext: updateResourceCssSnapshotConfigurationV1,
 		DeleteContext: deleteResourceCssSnapshotConfigurationV1,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(30 * time.Minute),
@@ -131,12 +135,13 @@ func readResourceCssSnapshotConfigurationV1(_ context.Context, d *schema.Resourc
 		"delete_auto": d.Get("creation_policy.0.delete_auto"),
 	}}
 	mErr := multierror.Append(
+		d.Set("cluster_id", clusterID),
 		d.Set("configuration", configuration),
 		d.Set("creation_policy", creation),
 		d.Set("base_path", info.BasePath),
 	)
 	if err := mErr.ErrorOrNil(); err != nil {
-		fmterr.Errorf("error setting snapshot configuration fields: %w", err)
+		return fmterr.Errorf("error setting snapshot configuration fields: %w", err)
 	}
 
 	return nil
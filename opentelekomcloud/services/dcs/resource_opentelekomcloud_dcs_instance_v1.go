@@ -2,12 +2,12 @@ package dcs
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/dcs/v1/instances"
@@ -15,6 +15,7 @@ import (
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common"
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/cfg"
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/fmterr"
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/waiter"
 )
 
 func ResourceDcsInstanceV1() *schema.Resource {
@@ -303,15 +304,17 @@ func resourceDcsInstancesV1Create(ctx context.Context, d *schema.ResourceData, m
 	}
 	log.Printf("[INFO] instance ID: %s", v.InstanceID)
 
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"CREATING"},
-		Target:     []string{"RUNNING"},
-		Refresh:    DcsInstancesV1StateRefreshFunc(DcsV1Client, v.InstanceID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
+	stateConf := &waiter.Config{
+		Description: fmt.Sprintf("DCS instance %s", v.InstanceID),
+		Pending:     []string{"CREATING"},
+		Target:      []string{"RUNNING"},
+		Error:       []string{"CREATEFAILED", "ERROR"},
+		Refresh:     DcsInstancesV1StateRefreshFunc(DcsV1Client, v.InstanceID),
+		Timeout:     d.Timeout(schema.TimeoutCreate),
+		Delay:       10 * time.Second,
+		MinInterval: 3 * time.Second,
 	}
-	_, err = stateConf.WaitForStateContext(ctx)
+	_, err = stateConf.Wait(ctx)
 	if err != nil {
 		return fmterr.Errorf(
 			"Error waiting for instance (%s) to become ready: %s",
@@ -424,16 +427,18 @@ func resourceDcsInstancesV1Delete(ctx context.Context, d *schema.ResourceData, m
 	// Wait for the instance to delete before moving on.
 	log.Printf("[DEBUG] Waiting for instance (%s) to delete", d.Id())
 
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"DELETING", "RUNNING"},
-		Target:     []string{"DELETED"},
-		Refresh:    DcsInstancesV1StateRefreshFunc(DcsV1Client, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
+	stateConf := &waiter.Config{
+		Description: fmt.Sprintf("DCS instance %s", d.Id()),
+		Pending:     []string{"DELETING", "RUNNING"},
+		Target:      []string{waiter.Deleted},
+		Error:       []string{"ERROR"},
+		Refresh:     DcsInstancesV1StateRefreshFunc(DcsV1Client, d.Id()),
+		Timeout:     d.Timeout(schema.TimeoutDelete),
+		Delay:       10 * time.Second,
+		MinInterval: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForStateContext(ctx)
+	_, err = stateConf.Wait(ctx)
 	if err != nil {
 		return fmterr.Errorf(
 			"Error waiting for instance (%s) to delete: %s",
@@ -445,13 +450,10 @@ func resourceDcsInstancesV1Delete(ctx context.Context, d *schema.ResourceData, m
 	return nil
 }
 
-func DcsInstancesV1StateRefreshFunc(client *golangsdk.ServiceClient, instanceID string) resource.StateRefreshFunc {
+func DcsInstancesV1StateRefreshFunc(client *golangsdk.ServiceClient, instanceID string) waiter.RefreshFunc {
 	return func() (interface{}, string, error) {
 		v, err := instances.Get(client, instanceID).Extract()
 		if err != nil {
-			if _, ok := err.(golangsdk.ErrDefault404); ok {
-				return v, "DELETED", nil
-			}
 			return nil, "", err
 		}
 
@@ -38,6 +38,8 @@ func ResourceDNSPtrRecordV2() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: common.SetTagsAllDiff,
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:             schema.TypeString,
@@ -57,7 +59,8 @@ func ResourceDNSPtrRecordV2() *schema.Resource {
 				Optional:     true,
 				ValidateFunc: validation.IntBetween(300, 2147483647),
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsAllSchema(),
 			"address": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -74,7 +77,7 @@ func resourceDNSPtrRecordV2Create(ctx context.Context, d *schema.ResourceData, m
 		return fmterr.Errorf("error creating OpenTelekomCloud DNS client: %s", err)
 	}
 
-	tagMap := d.Get("tags").(map[string]interface{})
+	tagMap := d.Get("tags_all").(map[string]interface{})
 	var tagList []ptrrecords.Tag
 	for k, v := range tagMap {
 		tag := ptrrecords.Tag{
@@ -156,7 +159,7 @@ func resourceDNSPtrRecordV2Read(_ context.Context, d *schema.ResourceData, meta
 	}
 
 	tagMap := common.TagsToMap(resourceTags)
-	if err := d.Set("tags", tagMap); err != nil {
+	if err := common.SetResourceTags(d, meta, tagMap); err != nil {
 		return fmterr.Errorf("error saving tags for OpenTelekomCloud DNS ptr record %s: %s", d.Id(), err)
 	}
 
@@ -171,7 +174,7 @@ func resourceDNSPtrRecordV2Update(ctx context.Context, d *schema.ResourceData, m
 		return fmterr.Errorf("error creating OpenTelekomCloud DNS client: %s", err)
 	}
 
-	tagMap := d.Get("tags").(map[string]interface{})
+	tagMap := d.Get("tags_all").(map[string]interface{})
 	var tagList []tags.ResourceTag
 	for k, v := range tagMap {
 		tag := tags.ResourceTag{
@@ -195,7 +198,7 @@ func resourceDNSPtrRecordV2Update(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if d.HasChange("tags_all") {
 		if err := common.UpdateResourceTags(client, d, "DNS-ptr_record", d.Id()); err != nil {
 			return fmterr.Errorf("error updating tags: %s", err)
 		}
@@ -31,7 +31,10 @@ func ResourceDNSRecordSetV2() *schema.Resource {
 			StateContext: common.ImportAsManaged,
 		},
 
-		CustomizeDiff: useSharedRecordSet,
+		CustomizeDiff: common.MultipleCustomizeDiffs(
+			common.SetTagsAllDiff,
+			useSharedRecordSet,
+		),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -85,7 +88,8 @@ func ResourceDNSRecordSetV2() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsAllSchema(),
 
 			"shared": {
 				Type:     schema.TypeBool,
@@ -160,7 +164,7 @@ func resourceDNSRecordSetV2Create(ctx context.Context, d *schema.ResourceData, m
 	d.SetId(id)
 
 	// set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := d.Get("tags_all").(map[string]interface{})
 	if len(tagRaw) > 0 {
 		resourceType, err := getDNSRecordSetResourceType(dnsClient, zoneID)
 		if err != nil {
@@ -222,7 +226,7 @@ func resourceDNSRecordSetV2Read(_ context.Context, d *schema.ResourceData, meta
 	}
 
 	tagmap := common.TagsToMap(resourceTags)
-	if err := d.Set("tags", tagmap); err != nil {
+	if err := common.SetResourceTags(d, meta, tagmap); err != nil {
 		return fmterr.Errorf("error saving tags for OpenTelekomCloud DNS record set %s: %s", recordsetID, err)
 	}
 
@@ -40,6 +40,8 @@ func ResourceDNSZoneV2() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: common.SetTagsAllDiff,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -72,7 +74,8 @@ func ResourceDNSZoneV2() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsAllSchema(),
 			"router": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -216,7 +219,7 @@ func resourceDNSZoneV2Create(ctx context.Context, d *schema.ResourceData, meta i
 	d.SetId(n.ID)
 
 	// set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := d.Get("tags_all").(map[string]interface{})
 	if len(tagRaw) > 0 {
 		taglist := common.ExpandResourceTags(tagRaw)
 		if tagErr := tags.Create(dnsClient, serviceMap[zone_type], n.ID, taglist).ExtractErr(); tagErr != nil {
@@ -259,7 +262,7 @@ func resourceDNSZoneV2Read(_ context.Context, d *schema.ResourceData, meta inter
 	}
 
 	tagmap := common.TagsToMap(resourceTags)
-	if err := d.Set("tags", tagmap); err != nil {
+	if err := common.SetResourceTags(d, meta, tagmap); err != nil {
 		return fmterr.Errorf("error saving tags for OpenTelekomCloud DNS zone %s: %s", d.Id(), err)
 	}
 
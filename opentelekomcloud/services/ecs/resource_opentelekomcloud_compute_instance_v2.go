@@ -48,6 +48,12 @@ func ResourceComputeInstanceV2() *schema.Resource {
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: common.MultipleCustomizeDiffs(
+			common.SetTagsAllDiff,
+			common.ValidateFlavor("availability_zone", "flavor_id", "flavor_name"),
+			common.ValidateQuota(common.RequireServerQuota("flavor_id", "flavor_name")),
+		),
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -317,7 +323,8 @@ func ResourceComputeInstanceV2() *schema.Resource {
 				}, true),
 				DiffSuppressFunc: suppressPowerStateDiffs,
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsAllSchema(),
 			"all_metadata": {
 				Type:     schema.TypeMap,
 				Computed: true,
@@ -494,7 +501,7 @@ func resourceComputeInstanceV2Create(ctx context.Context, d *schema.ResourceData
 	}
 
 	// set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := d.Get("tags_all").(map[string]interface{})
 	if len(tagRaw) > 0 {
 		computeClient, err := config.ComputeV1Client(config.GetRegion(d))
 		if err != nil {
@@ -658,7 +665,7 @@ func resourceComputeInstanceV2Read(ctx context.Context, d *schema.ResourceData,
 		return fmterr.Errorf("error fetching OpenTelekomCloud CloudServers tags: %w", err)
 	}
 	tagMap := common.TagsToMap(resourceTags)
-	mErr = multierror.Append(mErr, d.Set("tags", tagMap))
+	mErr = multierror.Append(mErr, common.SetResourceTags(d, meta, tagMap))
 
 	if err := mErr.ErrorOrNil(); err != nil {
 		return fmterr.Errorf("error setting opentelekomcloud_compute_instance_v2 values: %w", err)
@@ -870,7 +877,7 @@ func resourceComputeInstanceV2Update(ctx context.Context, d *schema.ResourceData
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if d.HasChange("tags_all") {
 		computeClient, err := config.ComputeV1Client(config.GetRegion(d))
 		if err != nil {
 			return fmterr.Errorf("error creating OpenTelekomCloud ComputeV1 client: %w", err)
@@ -39,9 +39,16 @@ func ResourceEcsInstanceV1() *schema.Resource {
 		},
 
 		CustomizeDiff: common.MultipleCustomizeDiffs(
+			common.SetTagsAllDiff,
 			common.ValidateVPC("vpc_id"),
 			common.ValidateVolumeType("system_disk_type"),
 			common.ValidateVolumeType("data_disks.*.type"),
+			common.ValidateFlavor("availability_zone", "flavor"),
+			common.ValidateQuota(
+				common.RequireServerQuota("flavor"),
+				common.RequireVolumeQuota("system_disk_size"),
+				common.RequireVolumeQuota("data_disks.*.size"),
+			),
 		),
 
 		Schema: map[string]*schema.Schema{
@@ -170,7 +177,8 @@ func ResourceEcsInstanceV1() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsAllSchema(),
 			"auto_recovery": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -226,7 +234,7 @@ func resourceEcsInstanceV1Create(ctx context.Context, d *schema.ResourceData, me
 	d.SetId(serverID.(string))
 
 	// set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := d.Get("tags_all").(map[string]interface{})
 	if len(tagRaw) > 0 {
 		tagList := common.ExpandResourceTags(tagRaw)
 		if err := tags.Create(client, "cloudservers", d.Id(), tagList).ExtractErr(); err != nil {
@@ -315,7 +323,7 @@ func resourceEcsInstanceV1Read(ctx context.Context, d *schema.ResourceData, meta
 		return fmterr.Errorf("error fetching OpenTelekomCloud CloudServers tags: %w", err)
 	}
 	tagMap := common.TagsToMap(resourceTags)
-	if err := d.Set("tags", tagMap); err != nil {
+	if err := common.SetResourceTags(d, meta, tagMap); err != nil {
 		return fmterr.Errorf("error saving tags for OpenTelekomCloud CloudServers: %w", err)
 	}
 
@@ -434,7 +442,7 @@ func resourceEcsInstanceV1Update(ctx context.Context, d *schema.ResourceData, me
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if d.HasChange("tags_all") {
 		computeClient, err := config.ComputeV1Client(config.GetRegion(d))
 		if err != nil {
 			return fmterr.Errorf(errCreateClient, err)
@@ -6,7 +6,6 @@ import (
 	"log"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
 
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/networking/v2/extensions/lbaas_v2/l7policies"
@@ -16,6 +15,7 @@ import (
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/networking/v2/extensions/lbaas_v2/pools"
 
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common"
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/waiter"
 )
 
 // lbPendingStatuses are the valid statuses a LoadBalancer will be in while
@@ -28,34 +28,21 @@ var lbPendingDeleteStatuses = []string{"ERROR", "PENDING_UPDATE", "PENDING_DELET
 var lbSkipLBStatuses = []string{"ERROR", "ACTIVE"}
 
 func waitForLBV2Listener(ctx context.Context, networkingClient *golangsdk.ServiceClient, id string, target string, pending []string, timeout time.Duration) error {
-	log.Printf("[DEBUG] Waiting for listener %s to become %s.", id, target)
-
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{target},
-		Pending:    pending,
-		Refresh:    resourceLBV2ListenerRefreshFunc(networkingClient, id),
-		Timeout:    timeout,
-		Delay:      5 * time.Second,
-		MinTimeout: 1 * time.Second,
+	conf := &waiter.Config{
+		Description: fmt.Sprintf("listener %s", id),
+		Target:      []string{target},
+		Pending:     pending,
+		Refresh:     resourceLBV2ListenerRefreshFunc(networkingClient, id),
+		Timeout:     timeout,
+		Delay:       5 * time.Second,
+		MinInterval: 1 * time.Second,
 	}
 
-	_, err := stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		if _, ok := err.(golangsdk.ErrDefault404); ok {
-			switch target {
-			case "DELETED":
-				return nil
-			default:
-				return fmt.Errorf("error: listener %s not found: %s", id, err)
-			}
-		}
-		return fmt.Errorf("error waiting for listener %s to become %s: %s", id, target, err)
-	}
-
-	return nil
+	_, err := conf.Wait(ctx)
+	return err
 }
 
-func resourceLBV2ListenerRefreshFunc(networkingClient *golangsdk.ServiceClient, id string) resource.StateRefreshFunc {
+func resourceLBV2ListenerRefreshFunc(networkingClient *golangsdk.ServiceClient, id string) waiter.RefreshFunc {
 	return func() (interface{}, string, error) {
 		listener, err := listeners.Get(networkingClient, id).Extract()
 		if err != nil {
@@ -68,34 +55,24 @@ func resourceLBV2ListenerRefreshFunc(networkingClient *golangsdk.ServiceClient,
 }
 
 func waitForLBV2LoadBalancer(ctx context.Context, networkingClient *golangsdk.ServiceClient, id string, target string, pending []string, timeout time.Duration) error {
-	log.Printf("[DEBUG] Waiting for loadbalancer %s to become %s.", id, target)
-
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{target},
-		Pending:    pending,
-		Refresh:    resourceLBV2LoadBalancerRefreshFunc(networkingClient, id),
-		Timeout:    timeout,
-		Delay:      5 * time.Second,
-		MinTimeout: 1 * time.Second,
+	conf := &waiter.Config{
+		Description: fmt.Sprintf("loadbalancer %s", id),
+		Target:      []string{target},
+		Pending:     pending,
+		Refresh:     resourceLBV2LoadBalancerRefreshFunc(networkingClient, id),
+		Timeout:     timeout,
+		Delay:       5 * time.Second,
+		MinInterval: 1 * time.Second,
 	}
-
-	_, err := stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		if _, ok := err.(golangsdk.ErrDefault404); ok {
-			switch target {
-			case "DELETED":
-				return nil
-			default:
-				return fmt.Errorf("error: loadbalancer %s not found: %s", id, err)
-			}
-		}
-		return fmt.Errorf("error waiting for loadbalancer %s to become %s: %s", id, target, err)
+	if target != waiter.Deleted {
+		conf.Error = []string{"ERROR"}
 	}
 
-	return nil
+	_, err := conf.Wait(ctx)
+	return err
 }
 
-func resourceLBV2LoadBalancerRefreshFunc(networkingClient *golangsdk.ServiceClient, id string) resource.StateRefreshFunc {
+func resourceLBV2LoadBalancerRefreshFunc(networkingClient *golangsdk.ServiceClient, id string) waiter.RefreshFunc {
 	return func() (interface{}, string, error) {
 		lb, err := loadbalancers.Get(networkingClient, id).Extract()
 		if err != nil {
@@ -107,34 +84,21 @@ func resourceLBV2LoadBalancerRefreshFunc(networkingClient *golangsdk.ServiceClie
 }
 
 func waitForLBV2Member(ctx context.Context, networkingClient *golangsdk.ServiceClient, poolID, memberID string, target string, pending []string, timeout time.Duration) error {
-	log.Printf("[DEBUG] Waiting for member %s to become %s.", memberID, target)
-
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{target},
-		Pending:    pending,
-		Refresh:    resourceLBV2MemberRefreshFunc(networkingClient, poolID, memberID),
-		Timeout:    timeout,
-		Delay:      5 * time.Second,
-		MinTimeout: 1 * time.Second,
+	conf := &waiter.Config{
+		Description: fmt.Sprintf("member %s", memberID),
+		Target:      []string{target},
+		Pending:     pending,
+		Refresh:     resourceLBV2MemberRefreshFunc(networkingClient, poolID, memberID),
+		Timeout:     timeout,
+		Delay:       5 * time.Second,
+		MinInterval: 1 * time.Second,
 	}
 
-	_, err := stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		if _, ok := err.(golangsdk.ErrDefault404); ok {
-			switch target {
-			case "DELETED":
-				return nil
-			default:
-				return fmt.Errorf("error: member %s not found: %s", memberID, err)
-			}
-		}
-		return fmt.Errorf("error waiting for member %s to become %s: %s", memberID, target, err)
-	}
-
-	return nil
+	_, err := conf.Wait(ctx)
+	return err
 }
 
-func resourceLBV2MemberRefreshFunc(networkingClient *golangsdk.ServiceClient, poolID, memberID string) resource.StateRefreshFunc {
+func resourceLBV2MemberRefreshFunc(networkingClient *golangsdk.ServiceClient, poolID, memberID string) waiter.RefreshFunc {
 	return func() (interface{}, string, error) {
 		member, err := pools.GetMember(networkingClient, poolID, memberID).Extract()
 		if err != nil {
@@ -147,34 +111,21 @@ func resourceLBV2MemberRefreshFunc(networkingClient *golangsdk.ServiceClient, po
 }
 
 func waitForLBV2Monitor(ctx context.Context, networkingClient *golangsdk.ServiceClient, id string, target string, pending []string, timeout time.Duration) error {
-	log.Printf("[DEBUG] Waiting for monitor %s to become %s.", id, target)
-
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{target},
-		Pending:    pending,
-		Refresh:    resourceLBV2MonitorRefreshFunc(networkingClient, id),
-		Timeout:    timeout,
-		Delay:      5 * time.Second,
-		MinTimeout: 1 * time.Second,
-	}
-
-	_, err := stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		if _, ok := err.(golangsdk.ErrDefault404); ok {
-			switch target {
-			case "DELETED":
-				return nil
-			default:
-				return fmt.Errorf("error: monitor %s not found: %s", id, err)
-			}
-		}
-		return fmt.Errorf("error waiting for monitor %s to become %s: %s", id, target, err)
+	conf := &waiter.Config{
+		Description: fmt.Sprintf("monitor %s", id),
+		Target:      []string{target},
+		Pending:     pending,
+		Refresh:     resourceLBV2MonitorRefreshFunc(networkingClient, id),
+		Timeout:     timeout,
+		Delay:       5 * time.Second,
+		MinInterval: 1 * time.Second,
 	}
 
-	return nil
+	_, err := conf.Wait(ctx)
+	return err
 }
 
-func resourceLBV2MonitorRefreshFunc(networkingClient *golangsdk.ServiceClient, id string) resource.StateRefreshFunc {
+func resourceLBV2MonitorRefreshFunc(networkingClient *golangsdk.ServiceClient, id string) waiter.RefreshFunc {
 	return func() (interface{}, string, error) {
 		monitor, err := monitors.Get(networkingClient, id).Extract()
 		if err != nil {
@@ -187,34 +138,21 @@ func resourceLBV2MonitorRefreshFunc(networkingClient *golangsdk.ServiceClient, i
 }
 
 func waitForLBV2Pool(ctx context.Context, networkingClient *golangsdk.ServiceClient, id string, target string, pending []string, timeout time.Duration) error {
-	log.Printf("[DEBUG] Waiting for pool %s to become %s.", id, target)
-
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{target},
-		Pending:    pending,
-		Refresh:    resourceLBV2PoolRefreshFunc(networkingClient, id),
-		Timeout:    timeout,
-		Delay:      5 * time.Second,
-		MinTimeout: 1 * time.Second,
+	conf := &waiter.Config{
+		Description: fmt.Sprintf("pool %s", id),
+		Target:      []string{target},
+		Pending:     pending,
+		Refresh:     resourceLBV2PoolRefreshFunc(networkingClient, id),
+		Timeout:     timeout,
+		Delay:       5 * time.Second,
+		MinInterval: 1 * time.Second,
 	}
 
-	_, err := stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		if _, ok := err.(golangsdk.ErrDefault404); ok {
-			switch target {
-			case "DELETED":
-				return nil
-			default:
-				return fmt.Errorf("error: pool %s not found: %s", id, err)
-			}
-		}
-		return fmt.Errorf("error waiting for pool %s to become %s: %s", id, target, err)
-	}
-
-	return nil
+	_, err := conf.Wait(ctx)
+	return err
 }
 
-func resourceLBV2PoolRefreshFunc(networkingClient *golangsdk.ServiceClient, poolID string) resource.StateRefreshFunc {
+func resourceLBV2PoolRefreshFunc(networkingClient *golangsdk.ServiceClient, poolID string) waiter.RefreshFunc {
 	return func() (interface{}, string, error) {
 		pool, err := pools.Get(networkingClient, poolID).Extract()
 		if err != nil {
@@ -255,7 +193,7 @@ func waitForLBV2viaPool(ctx context.Context, networkingClient *golangsdk.Service
 	return fmt.Errorf("No Load Balancer on pool %s", id)
 }
 
-func resourceLBV2LoadBalancerStatusRefreshFuncNeutron(lbClient *golangsdk.ServiceClient, lbID, resourceType, resourceID string) resource.StateRefreshFunc {
+func resourceLBV2LoadBalancerStatusRefreshFuncNeutron(lbClient *golangsdk.ServiceClient, lbID, resourceType, resourceID string) waiter.RefreshFunc {
 	return func() (interface{}, string, error) {
 		statuses, err := loadbalancers.GetStatuses(lbClient, lbID).Extract()
 		if err != nil {
@@ -310,7 +248,7 @@ func resourceLBV2LoadBalancerStatusRefreshFuncNeutron(lbClient *golangsdk.Servic
 					}
 				}
 			}
-			return "", "DELETED", nil
+			return "", waiter.Deleted, nil
 
 		case "l7policy":
 			for _, listener := range statuses.Loadbalancer.Listeners {
@@ -337,14 +275,14 @@ func resourceLBV2LoadBalancerStatusRefreshFuncNeutron(lbClient *golangsdk.Servic
 					}
 				}
 			}
-			return "", "DELETED", nil
+			return "", waiter.Deleted, nil
 		}
 
 		return nil, "", fmt.Errorf("An unexpected error occurred querying the status of %s %s by loadbalancer %s", resourceType, resourceID, lbID)
 	}
 }
 
-func resourceLBV2L7PolicyRefreshFunc(lbClient *golangsdk.ServiceClient, lbID string, l7policy *l7policies.L7Policy) resource.StateRefreshFunc {
+func resourceLBV2L7PolicyRefreshFunc(lbClient *golangsdk.ServiceClient, lbID string, l7policy *l7policies.L7Policy) waiter.RefreshFunc {
 	if l7policy.ProvisioningStatus != "" {
 		return func() (interface{}, string, error) {
 			lb, status, err := resourceLBV2LoadBalancerRefreshFunc(lbClient, lbID)()
@@ -368,35 +306,24 @@ func resourceLBV2L7PolicyRefreshFunc(lbClient *golangsdk.ServiceClient, lbID str
 }
 
 func waitForLBV2L7Policy(ctx context.Context, lbClient *golangsdk.ServiceClient, parentListener *listeners.Listener, l7policy *l7policies.L7Policy, target string, pending []string, timeout time.Duration) error {
-	log.Printf("[DEBUG] Waiting for l7policy %s to become %s.", l7policy.ID, target)
-
 	if len(parentListener.Loadbalancers) == 0 {
 		return fmt.Errorf("Unable to determine loadbalancer ID from listener %s", parentListener.ID)
 	}
 
 	lbID := parentListener.Loadbalancers[0].ID
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{target},
-		Pending:    pending,
-		Refresh:    resourceLBV2L7PolicyRefreshFunc(lbClient, lbID, l7policy),
-		Timeout:    timeout,
-		Delay:      1 * time.Second,
-		MinTimeout: 1 * time.Second,
+	conf := &waiter.Config{
+		Description: fmt.Sprintf("l7policy %s", l7policy.ID),
+		Target:      []string{target},
+		Pending:     pending,
+		Refresh:     resourceLBV2L7PolicyRefreshFunc(lbClient, lbID, l7policy),
+		Timeout:     timeout,
+		Delay:       1 * time.Second,
+		MinInterval: 1 * time.Second,
 	}
 
-	_, err := stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		if _, ok := err.(golangsdk.ErrDefault404); ok {
-			if target == "DELETED" {
-				return nil
-			}
-		}
-
-		return fmt.Errorf("error waiting for l7policy %s to become %s: %s", l7policy.ID, target, err)
-	}
-
-	return nil
+	_, err := conf.Wait(ctx)
+	return err
 }
 
 func getListenerIDForL7Policy(lbClient *golangsdk.ServiceClient, id string) (string, error) {
@@ -428,7 +355,7 @@ func getListenerIDForL7Policy(lbClient *golangsdk.ServiceClient, id string) (str
 	return "", fmt.Errorf("Unable to find Listener ID associated with the %s L7 Policy ID", id)
 }
 
-func resourceLBV2L7RuleRefreshFunc(lbClient *golangsdk.ServiceClient, lbID string, l7policyID string, l7rule *l7policies.Rule) resource.StateRefreshFunc {
+func resourceLBV2L7RuleRefreshFunc(lbClient *golangsdk.ServiceClient, lbID string, l7policyID string, l7rule *l7policies.Rule) waiter.RefreshFunc {
 	if l7rule.ProvisioningStatus != "" {
 		return func() (interface{}, string, error) {
 			lb, status, err := resourceLBV2LoadBalancerRefreshFunc(lbClient, lbID)()
@@ -452,35 +379,24 @@ func resourceLBV2L7RuleRefreshFunc(lbClient *golangsdk.ServiceClient, lbID strin
 }
 
 func waitForLBV2L7Rule(ctx context.Context, lbClient *golangsdk.ServiceClient, parentListener *listeners.Listener, parentL7policy *l7policies.L7Policy, l7rule *l7policies.Rule, target string, pending []string, timeout time.Duration) error {
-	log.Printf("[DEBUG] Waiting for l7rule %s to become %s.", l7rule.ID, target)
-
 	if len(parentListener.Loadbalancers) == 0 {
 		return fmt.Errorf("Unable to determine loadbalancer ID from listener %s", parentListener.ID)
 	}
 
 	lbID := parentListener.Loadbalancers[0].ID
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{target},
-		Pending:    pending,
-		Refresh:    resourceLBV2L7RuleRefreshFunc(lbClient, lbID, parentL7policy.ID, l7rule),
-		Timeout:    timeout,
-		Delay:      1 * time.Second,
-		MinTimeout: 1 * time.Second,
-	}
-
-	_, err := stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		if _, ok := err.(golangsdk.ErrDefault404); ok {
-			if target == "DELETED" {
-				return nil
-			}
-		}
-
-		return fmt.Errorf("error waiting for l7rule %s to become %s: %s", l7rule.ID, target, err)
+	conf := &waiter.Config{
+		Description: fmt.Sprintf("l7rule %s", l7rule.ID),
+		Target:      []string{target},
+		Pending:     pending,
+		Refresh:     resourceLBV2L7RuleRefreshFunc(lbClient, lbID, parentL7policy.ID, l7rule),
+		Timeout:     timeout,
+		Delay:       1 * time.Second,
+		MinInterval: 1 * time.Second,
 	}
 
-	return nil
+	_, err := conf.Wait(ctx)
+	return err
 }
 
 const classicLBDeprecated = "Classic load balancers are no longer provided. Please use elastic load balancers instead."
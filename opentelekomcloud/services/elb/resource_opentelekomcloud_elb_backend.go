@@ -23,6 +23,10 @@ func ResourceBackend() *schema.Resource {
 		ReadContext:   resourceBackendRead,
 		DeleteContext: resourceBackendDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: common.ImportByPath("listener_id", "id"),
+		},
+
 		DeprecationMessage: classicLBDeprecated,
 
 		Timeouts: &schema.ResourceTimeout{
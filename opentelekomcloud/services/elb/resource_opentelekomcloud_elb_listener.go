@@ -22,6 +22,10 @@ func ResourceEListener() *schema.Resource {
 		UpdateContext: resourceEListenerUpdate,
 		DeleteContext: resourceEListenerDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		DeprecationMessage: classicLBDeprecated,
 
 		Timeouts: &schema.ResourceTimeout{
@@ -235,11 +239,10 @@ func resourceEListenerRead(_ context.Context, d *schema.ResourceData, meta inter
 	d.Set("backend_protocol", listener.BackendProtocol)
 	d.Set("session_sticky_type", listener.StickySessionType)
 	d.Set("description", listener.Description)
-	d.Set("load_balancer_id", listener.LoadbalancerID)
+	d.Set("loadbalancer_id", listener.LoadbalancerID)
 	d.Set("protocol", listener.Protocol)
 	d.Set("protocol_port", listener.ProtocolPort)
 	d.Set("cookie_timeout", listener.CookieTimeout)
-	d.Set("admin_state_up", listener.AdminStateUp)
 	d.Set("session_sticky", listener.SessionSticky)
 	d.Set("lb_algorithm", listener.Algorithm)
 	d.Set("name", listener.Name)
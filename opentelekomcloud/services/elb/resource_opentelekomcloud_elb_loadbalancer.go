@@ -24,6 +24,10 @@ func ResourceELoadBalancer() *schema.Resource {
 		UpdateContext: resourceELoadBalancerUpdate,
 		DeleteContext: resourceELoadBalancerDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		DeprecationMessage: classicLBDeprecated,
 
 		Timeouts: &schema.ResourceTimeout{
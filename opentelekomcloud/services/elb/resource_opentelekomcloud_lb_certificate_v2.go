@@ -27,6 +27,10 @@ func ResourceCertificateV2() *schema.Resource {
 		UpdateContext: resourceCertificateV2Update,
 		DeleteContext: resourceCertificateV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
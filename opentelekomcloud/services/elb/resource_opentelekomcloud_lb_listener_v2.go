@@ -26,12 +26,18 @@ func ResourceListenerV2() *schema.Resource {
 		UpdateContext: resourceListenerV2Update,
 		DeleteContext: resourceListenerV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: common.SetTagsAllDiff,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -116,7 +122,8 @@ func ResourceListenerV2() *schema.Resource {
 				Default:  true,
 				Optional: true,
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsAllSchema(),
 		},
 	}
 }
@@ -185,7 +192,7 @@ func resourceListenerV2Create(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	// set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := d.Get("tags_all").(map[string]interface{})
 	if len(tagRaw) > 0 {
 		tagList := common.ExpandResourceTags(tagRaw)
 		if err := tags.Create(client, "listeners", listener.ID, tagList).ExtractErr(); err != nil {
@@ -228,6 +235,9 @@ func resourceListenerV2Read(_ context.Context, d *schema.ResourceData, meta inte
 		d.Set("tls_ciphers_policy", listener.TlsCiphersPolicy),
 		d.Set("admin_state_up", listener.AdminStateUp),
 	)
+	if len(listener.Loadbalancers) != 0 {
+		mErr = multierror.Append(mErr, d.Set("loadbalancer_id", listener.Loadbalancers[0].ID))
+	}
 
 	if mErr.ErrorOrNil() != nil {
 		return diag.FromErr(mErr)
@@ -239,7 +249,7 @@ func resourceListenerV2Read(_ context.Context, d *schema.ResourceData, meta inte
 		return fmterr.Errorf("error fetching OpenTelekomCloud LB Listener tags: %s", err)
 	}
 	tagMap := common.TagsToMap(resourceTags)
-	if err := d.Set("tags", tagMap); err != nil {
+	if err := common.SetResourceTags(d, meta, tagMap); err != nil {
 		return fmterr.Errorf("error saving tags for OpenTelekomCloud LB Listener: %s", err)
 	}
 
@@ -317,7 +327,7 @@ func resourceListenerV2Update(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if d.HasChange("tags_all") {
 		if err := common.UpdateResourceTags(client, d, "listeners", d.Id()); err != nil {
 			return fmterr.Errorf("error updating tags of LoadBalancer Listener %s: %s", d.Id(), err)
 		}
@@ -24,12 +24,18 @@ func ResourceLoadBalancerV2() *schema.Resource {
 		UpdateContext: resourceLoadBalancerV2Update,
 		DeleteContext: resourceLoadBalancerV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 
+		CustomizeDiff: common.SetTagsAllDiff,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -78,7 +84,8 @@ func ResourceLoadBalancerV2() *schema.Resource {
 				Computed: true,
 				ForceNew: true,
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsAllSchema(),
 		},
 	}
 }
@@ -120,7 +127,7 @@ func resourceLoadBalancerV2Create(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	// set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := d.Get("tags_all").(map[string]interface{})
 	if len(tagRaw) > 0 {
 		tagList := common.ExpandResourceTags(tagRaw)
 		if err := tags.Create(client, "loadbalancers", lb.ID, tagList).ExtractErr(); err != nil {
@@ -170,7 +177,7 @@ func resourceLoadBalancerV2Read(_ context.Context, d *schema.ResourceData, meta
 		return fmterr.Errorf("error fetching OpenTelekomCloud LoadCalancer tags: %s", err)
 	}
 	tagMap := common.TagsToMap(resourceTags)
-	if err := d.Set("tags", tagMap); err != nil {
+	if err := common.SetResourceTags(d, meta, tagMap); err != nil {
 		return fmterr.Errorf("error saving tags for OpenTelekomCloud LoadCalancer: %s", err)
 	}
 
@@ -222,7 +229,7 @@ func resourceLoadBalancerV2Update(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if d.HasChange("tags_all") {
 		if err := common.UpdateResourceTags(client, d, "loadbalancers", d.Id()); err != nil {
 			return fmterr.Errorf("error updating tags of LoadBalancer %s: %s", d.Id(), err)
 		}
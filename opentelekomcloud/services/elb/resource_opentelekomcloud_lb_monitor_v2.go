@@ -25,6 +25,10 @@ func ResourceMonitorV2() *schema.Resource {
 		UpdateContext: resourceMonitorV2Update,
 		DeleteContext: resourceMonitorV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -193,6 +197,9 @@ func resourceMonitorV2Read(_ context.Context, d *schema.ResourceData, meta inter
 		d.Set("region", config.GetRegion(d)),
 		d.Set("domain_name", monitor.DomainName),
 	)
+	if len(monitor.Pools) != 0 {
+		mErr = multierror.Append(mErr, d.Set("pool_id", monitor.Pools[0].ID))
+	}
 	if mErr.ErrorOrNil() != nil {
 		return diag.FromErr(mErr)
 	}
@@ -26,6 +26,10 @@ func ResourceLBPoolV2() *schema.Resource {
 		UpdateContext: resourceLBPoolV2Update,
 		DeleteContext: resourceLBPoolV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -239,14 +243,26 @@ func resourceLBPoolV2Read(_ context.Context, d *schema.ResourceData, meta interf
 		d.Set("region", config.GetRegion(d)),
 	)
 
+	// pool created for the listener is bound to the load balancer too, only one of them is set
+	if len(pool.Listeners) != 0 && d.Get("loadbalancer_id").(string) == "" {
+		mErr = multierror.Append(mErr, d.Set("listener_id", pool.Listeners[0].ID))
+	} else if len(pool.Loadbalancers) != 0 {
+		mErr = multierror.Append(mErr, d.Set("loadbalancer_id", pool.Loadbalancers[0].ID))
+	}
+
+	var persistence []map[string]interface{}
+	if pool.Persistence.Type != "" {
+		persistence = append(persistence, map[string]interface{}{
+			"type":        pool.Persistence.Type,
+			"cookie_name": pool.Persistence.CookieName,
+		})
+	}
+	mErr = multierror.Append(mErr, d.Set("persistence", persistence))
+
 	if mErr.ErrorOrNil() != nil {
 		return diag.FromErr(mErr)
 	}
 
-	log.Printf("[DEBUG] pool persistence: %+v", pool.Persistence)
-	// UNDONE: This needs to check for failure, and it currently fails...
-	// d.Set("persistence", pool.Persistence);
-
 	return nil
 }
 
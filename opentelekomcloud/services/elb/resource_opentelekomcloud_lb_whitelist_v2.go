@@ -21,6 +21,10 @@ func ResourceWhitelistV2() *schema.Resource {
 		UpdateContext: resourceWhitelistV2Update,
 		DeleteContext: resourceWhitelistV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -40,7 +40,10 @@ func ResourceBlockStorageVolumeV2() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
-		CustomizeDiff: customdiff.ForceNewIfChange("size", isDownScale),
+		CustomizeDiff: common.MultipleCustomizeDiffs(
+			customdiff.ForceNewIfChange("size", isDownScale),
+			common.ValidateQuota(common.RequireVolumeQuota("size")),
+		),
 
 		Schema: map[string]*schema.Schema{
 			"region": {
@@ -291,7 +294,7 @@ OUTER:
 	if err != nil {
 		return fmterr.Errorf("error fetching tags for volume (%s): %s", v.ID, err)
 	}
-	d.Set("tags", taglist)
+	d.Set("tags", common.FilterIgnoredTags(meta, taglist.Tags))
 
 	// This is useful for import
 	if d.Get("device_type").(string) == "" {
@@ -338,7 +341,9 @@ func resourceBlockStorageVolumeV2Update(ctx context.Context, d *schema.ResourceD
 		return fmterr.Errorf("error updating OpenTelekomCloud volume: %s", err)
 	}
 	if d.HasChange("tags") {
-		_, err = resourceEVSTagV2Create(ctx, d, meta, "volumes", d.Id(), resourceContainerTags(d))
+		if err := resourceEVSTagV2Update(ctx, d, meta, "volumes", d.Id(), resourceContainerTags(d)); err != nil {
+			return fmterr.Errorf("error updating tags for volume (%s): %s", d.Id(), err)
+		}
 	}
 
 	if d.HasChange("size") {
@@ -30,3 +30,19 @@ func resourceEVSTagV2Get(d *schema.ResourceData, meta interface{}, resourceType,
 
 	return tags.Get(client, resourceType, resourceID).Extract()
 }
+
+// resourceEVSTagV2Update replaces the resource tags keeping the tags matching provider `ignore_tags`
+func resourceEVSTagV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}, resourceType, resourceID string, tag map[string]string) error {
+	config := meta.(*cfg.Config)
+	current, err := resourceEVSTagV2Get(d, meta, resourceType, resourceID)
+	if err != nil {
+		return err
+	}
+	for k, v := range current.Tags {
+		if config.IgnoreTags.Ignored(k) {
+			tag[k] = v
+		}
+	}
+	_, err = resourceEVSTagV2Create(ctx, d, meta, resourceType, resourceID, tag)
+	return err
+}
@@ -40,8 +40,10 @@ func ResourceEvsStorageVolumeV3() *schema.Resource {
 		},
 
 		CustomizeDiff: common.MultipleCustomizeDiffs(
+			common.SetTagsAllDiff,
 			common.ValidateVolumeType("volume_type"),
 			customdiff.ForceNewIfChange("size", isDownScale),
+			common.ValidateQuota(common.RequireVolumeQuota("size")),
 		),
 
 		Schema: map[string]*schema.Schema{
@@ -92,7 +94,8 @@ func ResourceEvsStorageVolumeV3() *schema.Resource {
 				Default:      "VBD",
 				ValidateFunc: validation.StringInSlice([]string{"VBD", "SCSI"}, true),
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsAllSchema(),
 			"attachment": {
 				Type:     schema.TypeSet,
 				Computed: true,
@@ -198,7 +201,7 @@ func resourceEvsVolumeV3Create(ctx context.Context, d *schema.ResourceData, meta
 		d.SetId(id)
 
 		// set tags
-		tagRaw := d.Get("tags").(map[string]interface{})
+		tagRaw := d.Get("tags_all").(map[string]interface{})
 		if len(tagRaw) > 0 {
 			tagList := common.ExpandResourceTags(tagRaw)
 			if err := tags.Create(client, "os-vendor-volumes", id, tagList).ExtractErr(); err != nil {
@@ -243,7 +246,7 @@ func resourceEvsVolumeV3Read(_ context.Context, d *schema.ResourceData, meta int
 		return fmterr.Errorf("error fetching OpenTelekomCloud SFS File System tags: %s", err)
 	}
 	tagMap := common.TagsToMap(resourceTags)
-	if err := d.Set("tags", tagMap); err != nil {
+	if err := common.SetResourceTags(d, meta, tagMap); err != nil {
 		return fmterr.Errorf("error saving tags for OpenTelekomCloud EVSv3 Volume: %s", err)
 	}
 
@@ -282,7 +285,7 @@ func resourceEvsVolumeV3Update(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if d.HasChange("tags_all") {
 		if err := common.UpdateResourceTags(client, d, "os-vendor-volumes", d.Id()); err != nil {
 			return fmterr.Errorf("error updating tags for EVSv3 Volume %s: %w", d.Id(), err)
 		}
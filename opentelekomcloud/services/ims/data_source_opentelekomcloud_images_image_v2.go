@@ -2,6 +2,7 @@ package ims
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"regexp"
 	"sort"
@@ -171,15 +172,25 @@ func dataSourceImagesImageV2Read(_ context.Context, d *schema.ResourceData, meta
 	log.Printf("[DEBUG] List Options: %#v", listOpts)
 
 	var image images.Image
-	allPages, err := images.List(client, listOpts).AllPages()
-	if err != nil {
-		return fmterr.Errorf("unable to query images: %s", err)
-	}
+	// images found are cached, so data sources with the same filters don't query the images again
+	cacheKey := fmt.Sprintf("images/%s/%#v", config.GetRegion(d), listOpts)
+	cachedImages, err := config.CachedLookup(cacheKey, func() (interface{}, error) {
+		allPages, err := images.List(client, listOpts).AllPages()
+		if err != nil {
+			return nil, fmt.Errorf("unable to query images: %s", err)
+		}
 
-	allImages, err := images.ExtractImages(allPages)
+		allImages, err := images.ExtractImages(allPages)
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve images: %s", err)
+		}
+		return allImages, nil
+	})
 	if err != nil {
-		return fmterr.Errorf("unable to retrieve images: %s", err)
+		return diag.FromErr(err)
 	}
+	// cached list is shared with other data sources, it is copied as the images are sorted in place
+	allImages := append([]images.Image{}, cachedImages.([]images.Image)...)
 
 	var filteredImages []images.Image
 	if nameRegex, ok := d.GetOk("name_regex"); ok {
@@ -225,7 +225,7 @@ func resourceImsDataImageV2Read(_ context.Context, d *schema.ResourceData, meta
 	for _, val := range Taglist.Tags {
 		tagmap[val.Key] = val.Value
 	}
-	if err := d.Set("tags", tagmap); err != nil {
+	if err := d.Set("tags", common.FilterIgnoredTags(meta, tagmap)); err != nil {
 		return fmterr.Errorf("[DEBUG] Error saving tags for OpenTelekomCloud image (%s): %s", d.Id(), err)
 	}
 	return nil
@@ -272,7 +272,7 @@ func resourceImsImageV2Read(_ context.Context, d *schema.ResourceData, meta inte
 	for _, val := range Taglist.Tags {
 		tagmap[val.Key] = val.Value
 	}
-	if err := d.Set("tags", tagmap); err != nil {
+	if err := d.Set("tags", common.FilterIgnoredTags(meta, tagmap)); err != nil {
 		return fmterr.Errorf("[DEBUG] Error saving tags for OpenTelekomCloud image (%s): %s", d.Id(), err)
 	}
 	return nil
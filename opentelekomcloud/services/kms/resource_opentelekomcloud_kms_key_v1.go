@@ -36,6 +36,8 @@ func ResourceKmsKeyV1() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: common.SetTagsAllDiff,
+
 		Schema: map[string]*schema.Schema{
 			"key_alias": {
 				Type:     schema.TypeString,
@@ -85,7 +87,8 @@ func ResourceKmsKeyV1() *schema.Resource {
 				Optional: true,
 				Default:  "7",
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsAllSchema(),
 		},
 	}
 }
@@ -139,7 +142,7 @@ func resourceKmsKeyV1Create(ctx context.Context, d *schema.ResourceData, meta in
 	}
 
 	// set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := d.Get("tags_all").(map[string]interface{})
 	if len(tagRaw) > 0 {
 		tagList := common.ExpandResourceTags(tagRaw)
 		if err := tags.Create(client, "kms", key.KeyID, tagList).ExtractErr(); err != nil {
@@ -195,7 +198,7 @@ func resourceKmsKeyV1Read(_ context.Context, d *schema.ResourceData, meta interf
 		return fmterr.Errorf("error fetching OpenTelekomCloud KMS tags: %s", err)
 	}
 	tagMap := common.TagsToMap(resourceTags)
-	if err := d.Set("tags", tagMap); err != nil {
+	if err := common.SetResourceTags(d, meta, tagMap); err != nil {
 		return fmterr.Errorf("error saving tags for OpenTelekomCloud KMS: %s", err)
 	}
 
@@ -259,7 +262,7 @@ func resourceKmsKeyV1Update(ctx context.Context, d *schema.ResourceData, meta in
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if d.HasChange("tags_all") {
 		if err := common.UpdateResourceTags(client, d, "kms", d.Id()); err != nil {
 			return fmterr.Errorf("error updating tags of KMS %s: %s", d.Id(), err)
 		}
@@ -786,7 +786,7 @@ func resourceClusterV1Read(_ context.Context, d *schema.ResourceData, meta inter
 	for _, val := range Taglist.Tags {
 		tagmap[val.Key] = val.Value
 	}
-	if err := d.Set("tags", tagmap); err != nil {
+	if err := d.Set("tags", common.FilterIgnoredTags(meta, tagmap)); err != nil {
 		return fmterr.Errorf("[DEBUG] Error saving tag to state for OpenTelekomCloud MRS cluster (%s): %s", d.Id(), err)
 	}
 	return nil
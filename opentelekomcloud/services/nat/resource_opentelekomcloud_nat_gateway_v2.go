@@ -25,6 +25,10 @@ func ResourceNatGatewayV2() *schema.Resource {
 		UpdateContext: resourceNatGatewayV2Update,
 		DeleteContext: resourceNatGatewayV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
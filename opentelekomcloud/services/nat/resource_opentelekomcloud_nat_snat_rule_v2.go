@@ -23,6 +23,10 @@ func ResourceNatSnatRuleV2() *schema.Resource {
 		ReadContext:   resourceNatSnatRuleV2Read,
 		DeleteContext: resourceNatSnatRuleV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
@@ -30,6 +30,8 @@ func ResourceObsBucket() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: common.SetTagsAllDiff,
+
 		Schema: map[string]*schema.Schema{
 			"bucket": {
 				Type:         schema.TypeString,
@@ -232,6 +234,7 @@ func ResourceObsBucket() *schema.Resource {
 				Type:     schema.TypeMap,
 				Optional: true,
 			},
+			"tags_all": common.TagsAllSchema(),
 			"force_destroy": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -318,7 +321,7 @@ func resourceObsBucketUpdate(ctx context.Context, d *schema.ResourceData, meta i
 		}
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChange("tags_all") {
 		if err := resourceObsBucketTagsUpdate(client, d); err != nil {
 			return diag.FromErr(err)
 		}
@@ -432,7 +435,7 @@ func resourceObsBucketRead(_ context.Context, d *schema.ResourceData, meta inter
 	}
 
 	// Read the tags
-	if err := setObsBucketTags(client, d); err != nil {
+	if err := setObsBucketTags(client, d, meta); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -474,7 +477,7 @@ func resourceObsBucketDelete(ctx context.Context, d *schema.ResourceData, meta i
 
 func resourceObsBucketTagsUpdate(client *obs.ObsClient, d *schema.ResourceData) error {
 	bucket := d.Get("bucket").(string)
-	tagMap := d.Get("tags").(map[string]interface{})
+	tagMap := d.Get("tags_all").(map[string]interface{})
 	var tagList []obs.Tag
 	for k, v := range tagMap {
 		tag := obs.Tag{
@@ -1101,13 +1104,13 @@ func setObsBucketCorsRules(client *obs.ObsClient, d *schema.ResourceData) error
 	return nil
 }
 
-func setObsBucketTags(client *obs.ObsClient, d *schema.ResourceData) error {
+func setObsBucketTags(client *obs.ObsClient, d *schema.ResourceData, meta interface{}) error {
 	bucket := d.Id()
 	output, err := client.GetBucketTagging(bucket)
 	if err != nil {
 		if obsError, ok := err.(obs.ObsError); ok {
 			if obsError.Code == "NoSuchTagSet" {
-				err = d.Set("tags", nil)
+				err = common.SetResourceTags(d, meta, nil)
 				return err
 			} else {
 				return fmt.Errorf("error getting tags of OBS bucket %s: %s,\n Reason: %s",
@@ -1122,7 +1125,7 @@ func setObsBucketTags(client *obs.ObsClient, d *schema.ResourceData) error {
 	for _, tag := range output.Tags {
 		tagMap[tag.Key] = tag.Value
 	}
-	if err := d.Set("tags", tagMap); err != nil {
+	if err := common.SetResourceTags(d, meta, tagMap); err != nil {
 		return fmt.Errorf("error saving tags of OBS bucket %s: %s", bucket, err)
 	}
 	return nil
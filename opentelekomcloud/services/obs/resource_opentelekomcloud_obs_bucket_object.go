@@ -3,6 +3,7 @@ package obs
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"strings"
@@ -24,6 +25,10 @@ func ResourceObsBucketObject() *schema.Resource {
 		UpdateContext: resourceObsBucketObjectPut,
 		DeleteContext: resourceObsBucketObjectDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceObsBucketObjectImport,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"bucket": {
 				Type:     schema.TypeString,
@@ -283,3 +288,21 @@ func resourceObsBucketObjectDelete(_ context.Context, d *schema.ResourceData, me
 
 	return nil
 }
+
+func resourceObsBucketObjectImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid format specified for OBS bucket object, must be <bucket>/<key>")
+	}
+
+	d.SetId(parts[1])
+	mErr := multierror.Append(
+		d.Set("bucket", parts[0]),
+		d.Set("key", parts[1]),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -22,6 +22,10 @@ func ResourceObsBucketPolicy() *schema.Resource {
 		UpdateContext: resourceObsBucketPolicyPut,
 		DeleteContext: resourceObsBucketPolicyDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"bucket": {
 				Type:     schema.TypeString,
@@ -90,6 +94,9 @@ func resourceObsBucketPolicyRead(_ context.Context, d *schema.ResourceData, meta
 		return fmterr.Errorf("error getting bucket policy")
 	}
 
+	if err := d.Set("bucket", d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := d.Set("policy", pol.Policy); err != nil {
 		return diag.FromErr(err)
 	}
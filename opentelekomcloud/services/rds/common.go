@@ -1,5 +1,71 @@
 package rds
 
+import (
+	"context"
+	"fmt"
+	"time"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/waiter"
+)
+
 const (
 	errCreateClient = "error creating RDSv3 client: %w"
 )
+
+// waitForRdsJob waits for the RDSv3 job (e.g. instance creation or volume resize) to complete
+func waitForRdsJob(ctx context.Context, client *golangsdk.ServiceClient, jobID string, timeout time.Duration) error {
+	conf := &waiter.Config{
+		Description: fmt.Sprintf("RDSv3 job %s", jobID),
+		Target:      []string{"Completed"},
+		Error:       []string{"Failed"},
+		Refresh:     rdsJobRefreshFunc(client, jobID),
+		Timeout:     timeout,
+		MinInterval: 10 * time.Second,
+	}
+	_, err := conf.Wait(ctx)
+	return err
+}
+
+func rdsJobRefreshFunc(client *golangsdk.ServiceClient, jobID string) waiter.RefreshFunc {
+	return func() (interface{}, string, error) {
+		// jobs are not project-scoped, unlike the other RDSv3 API
+		url := fmt.Sprintf("%sjobs?id=%s", client.Endpoint, jobID)
+		job := new(golangsdk.RDSJobStatus)
+		_, err := client.Get(url, job, &golangsdk.RequestOpts{
+			MoreHeaders: map[string]string{"Content-Type": "application/json"},
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		return job, job.Job.Status, nil
+	}
+}
+
+// waitForRdsInstanceActive waits for the RDSv3 instance to become available for the changes
+func waitForRdsInstanceActive(ctx context.Context, client *golangsdk.ServiceClient, id string, timeout time.Duration) error {
+	conf := &waiter.Config{
+		Description: fmt.Sprintf("RDSv3 instance %s", id),
+		Target:      []string{"ACTIVE"},
+		Error:       []string{"FAILED"},
+		Refresh:     rdsInstanceRefreshFunc(client, id),
+		Timeout:     timeout,
+		MinInterval: 5 * time.Second,
+	}
+	_, err := conf.Wait(ctx)
+	return err
+}
+
+func rdsInstanceRefreshFunc(client *golangsdk.ServiceClient, id string) waiter.RefreshFunc {
+	return func() (interface{}, string, error) {
+		instance, err := GetRdsInstance(client, id)
+		if err != nil {
+			return nil, "", err
+		}
+		if instance == nil {
+			return nil, "", nil
+		}
+		return instance, instance.Status, nil
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -43,7 +44,11 @@ func ResourceRdsInstanceV3() *schema.Resource {
 			Update: schema.DefaultTimeout(30 * time.Minute),
 		},
 
-		CustomizeDiff: validateRDSv3Version("db"),
+		CustomizeDiff: common.MultipleCustomizeDiffs(
+			setRdsTagsAllDiff,
+			validateRDSv3Version("db"),
+			validateRDSv3Flavor("flavor"),
+		),
 
 		Schema: map[string]*schema.Schema{
 			"availability_zone": {
@@ -182,6 +187,7 @@ func ResourceRdsInstanceV3() *schema.Resource {
 				ValidateFunc:  common.ValidateTags,
 				ConflictsWith: []string{"tag"},
 			},
+			"tags_all": common.TagsAllSchema(),
 			"param_group_id": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -349,7 +355,7 @@ func resourceRdsInstanceV3Create(ctx context.Context, d *schema.ResourceData, me
 	}
 
 	timeout := d.Timeout(schema.TimeoutCreate)
-	if err := instances.WaitForJobCompleted(client, int(timeout.Seconds()), jobResponse.JobID); err != nil {
+	if err := waitForRdsJob(ctx, client, jobResponse.JobID, timeout); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -384,8 +390,8 @@ func resourceRdsInstanceV3Create(ctx context.Context, d *schema.ResourceData, me
 		}
 	}
 
-	if common.HasFilledOpt(d, "tags") {
-		tagRaw := d.Get("tags").(map[string]interface{})
+	if !common.HasFilledOpt(d, "tag") {
+		tagRaw := d.Get("tags_all").(map[string]interface{})
 		if len(tagRaw) > 0 {
 			tagList := common.ExpandResourceTags(tagRaw)
 			if err := tags.Create(client, "instances", r.Instance.Id, tagList).ExtractErr(); err != nil {
@@ -412,14 +418,14 @@ func resourceRdsInstanceV3Create(ctx context.Context, d *schema.ResourceData, me
 		}
 	}
 
-	if err := assureTemplateApplied(client, d); err != nil {
+	if err := assureTemplateApplied(ctx, client, d); err != nil {
 		return fmterr.Errorf("error making sure configuration template is applied: %w", err)
 	}
 
 	return resourceRdsInstanceV3Read(ctx, d, meta)
 }
 
-func assureTemplateApplied(client *golangsdk.ServiceClient, d *schema.ResourceData) error {
+func assureTemplateApplied(ctx context.Context, client *golangsdk.ServiceClient, d *schema.ResourceData) error {
 	templateID := d.Get("param_group_id").(string)
 	if templateID == "" {
 		return nil
@@ -455,10 +461,10 @@ func assureTemplateApplied(client *golangsdk.ServiceClient, d *schema.ResourceDa
 		return nil
 	}
 
-	return applyAndRestart(client, d)
+	return applyAndRestart(ctx, client, d)
 }
 
-func applyAndRestart(client *golangsdk.ServiceClient, d *schema.ResourceData) error {
+func applyAndRestart(ctx context.Context, client *golangsdk.ServiceClient, d *schema.ResourceData) error {
 	templateID := d.Get("param_group_id").(string)
 	applyResult, err := configurations.Apply(client, templateID, configurations.ApplyOpts{
 		InstanceIDs: []string{d.Id()},
@@ -484,9 +490,8 @@ func applyAndRestart(client *golangsdk.ServiceClient, d *schema.ResourceData) er
 		return nil
 	}
 
-	waitSeconds := int(d.Timeout(schema.TimeoutCreate).Seconds())
-	err = instances.WaitForStateAvailable(client, waitSeconds, d.Id())
-	if err != nil {
+	timeout := d.Timeout(schema.TimeoutCreate)
+	if err := waitForRdsInstanceActive(ctx, client, d.Id(), timeout); err != nil {
 		return err
 	}
 
@@ -494,8 +499,7 @@ func applyAndRestart(client *golangsdk.ServiceClient, d *schema.ResourceData) er
 	if err != nil {
 		return fmt.Errorf("error restarting RDS instance: %w", err)
 	}
-	timeout := d.Timeout(schema.TimeoutCreate)
-	if err := instances.WaitForJobCompleted(client, int(timeout.Seconds()), job.JobId); err != nil {
+	if err := waitForRdsJob(ctx, client, job.JobId, timeout); err != nil {
 		return fmt.Errorf("error waiting for instance to reboot: %w", err)
 	}
 	return nil
@@ -672,7 +676,7 @@ func resourceRdsInstanceV3Update(ctx context.Context, d *schema.ResourceData, me
 			}
 		}
 	}
-	if d.HasChange("tags") {
+	if d.HasChange("tags_all") {
 		if err := common.UpdateResourceTags(client, d, "instances", d.Id()); err != nil {
 			return fmterr.Errorf("error updating tags of RDSv3 instance %s: %s", d.Id(), err)
 		}
@@ -714,8 +718,8 @@ func resourceRdsInstanceV3Update(ctx context.Context, d *schema.ResourceData, me
 		}
 
 		log.Printf("Update flavor could be done only in status `available`")
-		if err := instances.WaitForStateAvailable(client, 1200, d.Id()); err != nil {
-			log.Printf("Status available wasn't present")
+		if err := waitForRdsInstanceActive(ctx, client, d.Id(), 20*time.Minute); err != nil {
+			log.Printf("[WARN] Status available wasn't present: %s", err)
 		}
 
 		log.Printf("[DEBUG] Update flavor: %s", newFlavor.(string))
@@ -725,8 +729,8 @@ func resourceRdsInstanceV3Update(ctx context.Context, d *schema.ResourceData, me
 		}
 
 		log.Printf("Waiting for RDSv3 become in status `available`")
-		if err := instances.WaitForStateAvailable(client, 1200, d.Id()); err != nil {
-			log.Printf("Status available wasn't present")
+		if err := waitForRdsInstanceActive(ctx, client, d.Id(), 20*time.Minute); err != nil {
+			log.Printf("[WARN] Status available wasn't present: %s", err)
 		}
 
 		log.Printf("[DEBUG] Successfully updated instance %s flavor: %s", d.Id(), d.Get("flavor").(string))
@@ -750,8 +754,8 @@ func resourceRdsInstanceV3Update(ctx context.Context, d *schema.ResourceData, me
 		}
 
 		log.Printf("Update volume size could be done only in status `available`")
-		if err := instances.WaitForStateAvailable(client, 1200, d.Id()); err != nil {
-			log.Printf("Status available wasn't present")
+		if err := waitForRdsInstanceActive(ctx, client, d.Id(), 20*time.Minute); err != nil {
+			log.Printf("[WARN] Status available wasn't present: %s", err)
 		}
 
 		updateResult, err := instances.EnlargeVolume(client, updateOpts, d.Id()).ExtractJobResponse()
@@ -759,7 +763,7 @@ func resourceRdsInstanceV3Update(ctx context.Context, d *schema.ResourceData, me
 			return fmterr.Errorf("error updating instance volume from result: %s", err)
 		}
 		timeout := d.Timeout(schema.TimeoutCreate)
-		if err := instances.WaitForJobCompleted(client, int(timeout.Seconds()), updateResult.JobID); err != nil {
+		if err := waitForRdsJob(ctx, client, updateResult.JobID, timeout); err != nil {
 			return diag.FromErr(err)
 		}
 
@@ -934,14 +938,8 @@ func resourceRdsInstanceV3Read(_ context.Context, d *schema.ResourceData, meta i
 		}
 	}
 
-	var tagParamName string
 	// set instance tags
-	if _, ok := d.GetOk("tags"); ok {
-		tagParamName = "tags"
-	} else if _, ok := d.GetOk("tag"); ok {
-		tagParamName = "tag"
-	}
-	if tagParamName == "tag" {
+	if _, ok := d.GetOk("tag"); ok {
 		// set instance tag
 		var nodeID string
 		nodes := d.Get("nodes").([]interface{})
@@ -971,9 +969,9 @@ func resourceRdsInstanceV3Read(_ context.Context, d *schema.ResourceData, meta i
 		if err := d.Set("tag", tagMap); err != nil {
 			return fmterr.Errorf("[DEBUG] Error saving tag to state for OpenTelekomCloud rds instance (%s): %s", d.Id(), err)
 		}
-	} else if tagParamName == "tags" {
+	} else {
 		tagsMap := common.TagsToMap(rdsInstance.Tags)
-		if err := d.Set("tags", tagsMap); err != nil {
+		if err := common.SetResourceTags(d, meta, tagsMap); err != nil {
 			return fmterr.Errorf("error saving tags for OpenTelekomCloud RDSv3 instance: %s", err)
 		}
 	}
@@ -999,6 +997,15 @@ func resourceRdsInstanceV3Delete(_ context.Context, d *schema.ResourceData, meta
 	return nil
 }
 
+// setRdsTagsAllDiff calculates `tags_all` unless deprecated `tag` is used,
+// provider `default_tags` are not applied together with `tag`
+func setRdsTagsAllDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if _, ok := d.GetOk("tag"); ok {
+		return nil
+	}
+	return common.SetTagsAllDiff(ctx, d, meta)
+}
+
 func validateRDSv3Version(argumentName string) schema.CustomizeDiffFunc {
 	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
 		config, ok := meta.(*cfg.Config)
@@ -1031,3 +1038,85 @@ func validateRDSv3Version(argumentName string) schema.CustomizeDiffFunc {
 		return nil
 	}
 }
+
+// getRdsV3Flavors returns flavors of the datastore version, the list is loaded once per run
+func getRdsV3Flavors(config *cfg.Config, region, datastoreType, datastoreVersion string) ([]flavors.Flavors, error) {
+	key := fmt.Sprintf("rds-flavors/%s/%s/%s", region, datastoreType, datastoreVersion)
+	flavorList, err := config.CachedLookup(key, func() (interface{}, error) {
+		client, err := config.RdsV3Client(region)
+		if err != nil {
+			return nil, fmt.Errorf("error creating OpenTelekomCloud RDSv3 Client: %s", err)
+		}
+		pages, err := flavors.List(client, flavors.DbFlavorsOpts{Versionname: datastoreVersion}, datastoreType).AllPages()
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve flavors: %s", err)
+		}
+		flavorList, err := flavors.ExtractDbFlavors(pages)
+		if err != nil {
+			return nil, err
+		}
+		return flavorList.Flavorslist, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return flavorList.([]flavors.Flavors), nil
+}
+
+// validateRDSv3Flavor checks that the flavor exists and is sold in all selected AZs
+func validateRDSv3Flavor(argumentName string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() != "" && !d.HasChange(argumentName) {
+			return nil
+		}
+		// values known only after the apply, e.g. computed by other resources, are not validated
+		for _, key := range []string{argumentName, "db.0.type", "db.0.version", "availability_zone"} {
+			if !d.NewValueKnown(key) {
+				return nil
+			}
+		}
+		config, ok := meta.(*cfg.Config)
+		if !ok {
+			return fmt.Errorf("error retreiving configuration: can't convert %v to Config", meta)
+		}
+
+		dataStoreInfo := d.Get("db").([]interface{})[0].(map[string]interface{})
+		flavorList, err := getRdsV3Flavors(config, config.GetRegion(d), dataStoreInfo["type"].(string), dataStoreInfo["version"].(string))
+		if err != nil {
+			log.Printf("[WARN] Flavors are not validated: %s", err)
+			return nil
+		}
+
+		flavorRef := d.Get(argumentName).(string)
+		var flavor *flavors.Flavors
+		for i := range flavorList {
+			if flavorList[i].Speccode == flavorRef {
+				flavor = &flavorList[i]
+				break
+			}
+		}
+		if flavor == nil {
+			return fmt.Errorf("can't find flavor `%s`", flavorRef)
+		}
+
+		var validAZs []string
+		for az, status := range flavor.Azstatus {
+			if status == "normal" {
+				validAZs = append(validAZs, az)
+			}
+		}
+		sort.Strings(validAZs)
+		for _, az := range d.Get("availability_zone").([]interface{}) {
+			if status := flavor.Azstatus[az.(string)]; status != "normal" {
+				if status == "" {
+					status = "unsupported"
+				}
+				return fmt.Errorf(
+					"flavor `%s` is not available in AZ `%s`, status: %s.\nAvailable in AZs: %v",
+					flavorRef, az, status, validAZs,
+				)
+			}
+		}
+		return nil
+	}
+}
@@ -162,9 +162,7 @@ func resourceRdsReadReplicaV3Create(ctx context.Context, d *schema.ResourceData,
 	}
 	d.SetId(job.Instance.Id)
 
-	timeoutSeconds := d.Timeout(schema.TimeoutCreate).Seconds()
-	err = instances.WaitForJobCompleted(client, int(timeoutSeconds), job.JobId)
-	if err != nil {
+	if err := waitForRdsJob(ctx, client, job.JobId, d.Timeout(schema.TimeoutCreate)); err != nil {
 		return fmterr.Errorf("error waiting for read replica to complete creation: %w", err)
 	}
 
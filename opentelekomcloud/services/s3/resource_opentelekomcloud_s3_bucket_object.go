@@ -31,6 +31,10 @@ func ResourceS3BucketObject() *schema.Resource {
 		UpdateContext: resourceS3BucketObjectPut,
 		DeleteContext: resourceS3BucketObjectDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceS3BucketObjectImport,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"bucket": {
 				Type:     schema.TypeString,
@@ -351,3 +355,20 @@ func validateS3BucketObjectServerSideEncryption(v interface{}, k string) (ws []s
 	}
 	return
 }
+
+func resourceS3BucketObjectImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid format specified for S3 bucket object, must be <bucket>/<key>")
+	}
+
+	d.SetId(parts[1])
+	if err := d.Set("bucket", parts[0]); err != nil {
+		return nil, err
+	}
+	if err := d.Set("key", parts[1]); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -24,6 +24,10 @@ func ResourceS3BucketPolicy() *schema.Resource {
 		UpdateContext: resourceS3BucketPolicyPut,
 		DeleteContext: resourceS3BucketPolicyDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"bucket": {
 				Type:     schema.TypeString,
@@ -95,6 +99,9 @@ func resourceS3BucketPolicyRead(_ context.Context, d *schema.ResourceData, meta
 	if err == nil && pol.Policy != nil {
 		v = *pol.Policy
 	}
+	if err := d.Set("bucket", d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := d.Set("policy", v); err != nil {
 		return diag.FromErr(err)
 	}
@@ -32,6 +32,8 @@ func ResourceSdrsProtectedInstanceV1() *schema.Resource {
 			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
+		CustomizeDiff: common.SetTagsAllDiff,
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -88,7 +90,8 @@ func ResourceSdrsProtectedInstanceV1() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsAllSchema(),
 		},
 	}
 }
@@ -127,7 +130,7 @@ func resourceSdrsProtectedInstanceV1Create(ctx context.Context, d *schema.Resour
 	d.SetId(instanceID.(string))
 
 	// set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := d.Get("tags_all").(map[string]interface{})
 	if len(tagRaw) > 0 {
 		tagList := common.ExpandResourceTags(tagRaw)
 		if err := tags.Create(client, "protected-instances", d.Id(), tagList).ExtractErr(); err != nil {
@@ -172,7 +175,7 @@ func resourceSdrsProtectedInstanceV1Read(_ context.Context, d *schema.ResourceDa
 		return fmterr.Errorf("error fetching OpenTelekomCloud SDRS Protected Instance tags: %s", err)
 	}
 	tagMap := common.TagsToMap(resourceTags)
-	if err := d.Set("tags", tagMap); err != nil {
+	if err := common.SetResourceTags(d, meta, tagMap); err != nil {
 		return fmterr.Errorf("error saving tags for OpenTelekomCloud SDRS Protected Instance: %s", err)
 	}
 
@@ -196,7 +199,7 @@ func resourceSdrsProtectedInstanceV1Update(ctx context.Context, d *schema.Resour
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if d.HasChange("tags_all") {
 		if err := common.UpdateResourceTags(client, d, "protected-instances", d.Id()); err != nil {
 			return fmterr.Errorf("error updating tags of SDRS Protected Instance %s: %s", d.Id(), err)
 		}
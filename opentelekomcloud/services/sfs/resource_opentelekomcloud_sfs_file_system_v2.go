@@ -35,6 +35,8 @@ func ResourceSFSFileSystemV2() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: common.SetTagsAllDiff,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -122,7 +124,8 @@ func ResourceSFSFileSystemV2() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsAllSchema(),
 		},
 	}
 }
@@ -186,7 +189,7 @@ func resourceSFSFileSystemV2Create(ctx context.Context, d *schema.ResourceData,
 	}
 
 	// set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := d.Get("tags_all").(map[string]interface{})
 	if len(tagRaw) > 0 {
 		tagList := common.ExpandResourceTags(tagRaw)
 		if err := tags.Create(client, "sfs", share.ID, tagList).ExtractErr(); err != nil {
@@ -251,7 +254,7 @@ func resourceSFSFileSystemV2Read(_ context.Context, d *schema.ResourceData, meta
 		return fmterr.Errorf("error fetching OpenTelekomCloud SFS File System tags: %s", err)
 	}
 	tagMap := common.TagsToMap(resourceTags)
-	if err := d.Set("tags", tagMap); err != nil {
+	if err := common.SetResourceTags(d, meta, tagMap); err != nil {
 		return fmterr.Errorf("error saving tags for OpenTelekomCloud SFS File System: %s", err)
 	}
 
@@ -348,7 +351,7 @@ func resourceSFSFileSystemV2Update(ctx context.Context, d *schema.ResourceData,
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if d.HasChange("tags_all") {
 		if err := common.UpdateResourceTags(client, d, "sfs", d.Id()); err != nil {
 			return fmterr.Errorf("error updating tags of SFS File System %s: %s", d.Id(), err)
 		}
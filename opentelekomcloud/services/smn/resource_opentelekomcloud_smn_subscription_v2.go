@@ -20,6 +20,10 @@ func ResourceSubscription() *schema.Resource {
 		ReadContext:   resourceSubscriptionRead,
 		DeleteContext: resourceSubscriptionDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"topic_urn": {
 				Type:     schema.TypeString,
@@ -138,9 +142,13 @@ func resourceSubscriptionRead(_ context.Context, d *schema.ResourceData, meta in
 			d.Set("owner", subscription.Owner)
 			d.Set("remark", subscription.Remark)
 			d.Set("status", subscription.Status)
+
+			log.Printf("[DEBUG] Successfully get subscription %s", id)
+			return nil
 		}
 	}
 
-	log.Printf("[DEBUG] Successfully get subscription %s", id)
+	log.Printf("[WARN] Subscription %s not found, removing from state", id)
+	d.SetId("")
 	return nil
 }
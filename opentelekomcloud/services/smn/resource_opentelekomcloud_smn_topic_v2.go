@@ -21,6 +21,10 @@ func ResourceTopic() *schema.Resource {
 		UpdateContext: resourceTopicUpdate,
 		DeleteContext: resourceTopicDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
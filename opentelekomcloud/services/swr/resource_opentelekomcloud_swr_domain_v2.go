@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/swr/v2/domains"
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common"
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/cfg"
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/fmterr"
 )
@@ -21,6 +22,10 @@ func ResourceSwrDomainV2() *schema.Resource {
 		UpdateContext: resourceSwrDomainUpdate,
 		DeleteContext: resourceSwrDomainDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: common.ImportByPath("organization", "repository", "id"),
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(2 * time.Minute),
 		},
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/swr/v2/organizations"
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common"
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/cfg"
 	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/fmterr"
 )
@@ -19,6 +20,10 @@ func ResourceSwrOrganizationPermissionsV2() *schema.Resource {
 		UpdateContext: resourceSwrOrganizationPermissionsV2Update,
 		DeleteContext: resourceSwrOrganizationPermissionsV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: common.ImportByPath("organization", "id"),
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(1 * time.Minute),
 		},
@@ -85,7 +90,9 @@ func resourceSwrOrganizationPermissionsV2Read(_ context.Context, d *schema.Resou
 	}
 
 	mErr := multierror.Append(
-		d.Set("auth", found.Auth), // username/id shouldn't change, I suppose
+		d.Set("user_id", found.UserID),
+		d.Set("username", found.Username),
+		d.Set("auth", found.Auth),
 	)
 	if err := mErr.ErrorOrNil(); err != nil {
 		return fmterr.Errorf("error setting permissions fields: %w", err)
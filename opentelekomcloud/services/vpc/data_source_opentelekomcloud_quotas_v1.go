@@ -0,0 +1,67 @@
+package vpc
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common"
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/cfg"
+	"github.com/opentelekomcloud/terraform-provider-opentelekomcloud/opentelekomcloud/common/fmterr"
+)
+
+func DataSourceQuotasV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceQuotasV1Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"used": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"limit": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+	}
+}
+
+func dataSourceQuotasV1Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*cfg.Config)
+	region := config.GetRegion(d)
+
+	quotas, err := common.NewQuotaLoader(config)(region)
+	if err != nil {
+		return fmterr.Errorf("error retrieving quotas: %s", err)
+	}
+
+	used := make(map[string]int)
+	limit := make(map[string]int)
+	for name, quota := range quotas {
+		used[name] = quota.Used
+		limit[name] = quota.Limit
+	}
+
+	d.SetId(region)
+	mErr := multierror.Append(
+		d.Set("region", region),
+		d.Set("used", used),
+		d.Set("limit", limit),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return fmterr.Errorf("error setting quotas fields: %s", err)
+	}
+
+	return nil
+}
@@ -23,6 +23,10 @@ func ResourceNetworkingRouterInterfaceV2() *schema.Resource {
 		ReadContext:   resourceNetworkingRouterInterfaceV2Read,
 		DeleteContext: resourceNetworkingRouterInterfaceV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
@@ -43,11 +47,13 @@ func ResourceNetworkingRouterInterfaceV2() *schema.Resource {
 			"subnet_id": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"port_id": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 		},
@@ -110,6 +116,11 @@ func resourceNetworkingRouterInterfaceV2Read(_ context.Context, d *schema.Resour
 
 	log.Printf("[DEBUG] Retrieved Router Interface %s: %+v", d.Id(), n)
 
+	d.Set("router_id", n.DeviceID)
+	d.Set("port_id", n.ID)
+	if len(n.FixedIPs) != 0 {
+		d.Set("subnet_id", n.FixedIPs[0].SubnetID)
+	}
 	d.Set("region", config.GetRegion(d))
 
 	return nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -21,6 +22,10 @@ func ResourceNetworkingRouterRouteV2() *schema.Resource {
 		ReadContext:   resourceNetworkingRouterRouteV2Read,
 		DeleteContext: resourceNetworkingRouterRouteV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceNetworkingRouterRouteV2Import,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -207,3 +212,20 @@ func resourceNetworkingRouterRouteV2Delete(_ context.Context, d *schema.Resource
 
 	return nil
 }
+
+func resourceNetworkingRouterRouteV2Import(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	routeIDParts := strings.SplitN(d.Id(), "-route-", 2)
+	if len(routeIDParts) != 2 {
+		return nil, fmt.Errorf("invalid format specified for router route, must be <router_id>-route-<destination_cidr>-<next_hop>")
+	}
+	routeParts := strings.SplitN(routeIDParts[1], "-", 2)
+	if len(routeParts) != 2 {
+		return nil, fmt.Errorf("invalid format specified for router route, must be <router_id>-route-<destination_cidr>-<next_hop>")
+	}
+
+	d.Set("router_id", routeIDParts[0])
+	d.Set("destination_cidr", routeParts[0])
+	d.Set("next_hop", routeParts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
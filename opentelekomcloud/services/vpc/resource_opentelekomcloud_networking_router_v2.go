@@ -24,6 +24,10 @@ func ResourceNetworkingRouterV2() *schema.Resource {
 		UpdateContext: resourceNetworkingRouterV2Update,
 		DeleteContext: resourceNetworkingRouterV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
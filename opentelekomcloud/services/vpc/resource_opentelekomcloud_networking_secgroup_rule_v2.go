@@ -32,6 +32,8 @@ func ResourceNetworkingSecGroupRuleV2() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: common.ValidateQuota(common.RequireQuota(common.QuotaSecurityGroupRules, 1)),
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
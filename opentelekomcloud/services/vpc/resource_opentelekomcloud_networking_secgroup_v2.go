@@ -34,6 +34,8 @@ func ResourceNetworkingSecGroupV2() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: common.ValidateQuota(common.RequireQuota(common.QuotaSecurityGroups, 1)),
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
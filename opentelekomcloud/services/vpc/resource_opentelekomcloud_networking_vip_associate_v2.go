@@ -21,6 +21,10 @@ func ResourceNetworkingVIPAssociateV2() *schema.Resource {
 		ReadContext:   resourceNetworkingVIPAssociateV2Read,
 		DeleteContext: resourceNetworkingVIPAssociateV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"vip_id": {
 				Type:     schema.TypeString,
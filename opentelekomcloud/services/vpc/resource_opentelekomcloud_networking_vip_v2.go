@@ -22,6 +22,10 @@ func ResourceNetworkingVIPV2() *schema.Resource {
 		ReadContext:   resourceNetworkingVIPV2Read,
 		DeleteContext: resourceNetworkingVIPV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"network_id": {
 				Type:     schema.TypeString,
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/common/tags"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/networking/v1/bandwidths"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/networking/v1/eips"
 
@@ -35,6 +36,11 @@ func ResourceVpcEIPV1() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: common.MultipleCustomizeDiffs(
+			common.SetTagsAllDiff,
+			common.ValidateQuota(common.RequireQuota(common.QuotaEIPs, 1)),
+		),
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -101,7 +107,8 @@ func ResourceVpcEIPV1() *schema.Resource {
 				Type:     schema.TypeMap,
 				Optional: true,
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsAllSchema(),
 		},
 	}
 }
@@ -142,8 +149,18 @@ func resourceVpcEIPV1Create(ctx context.Context, d *schema.ResourceData, meta in
 
 	d.SetId(eip.ID)
 
-	if err := addNetworkingTags(d, config, "publicips"); err != nil {
-		return diag.FromErr(err)
+	// set tags
+	tagRaw := d.Get("tags_all").(map[string]interface{})
+	if len(tagRaw) > 0 {
+		networkingV2Client, err := config.NetworkingV2Client(config.GetRegion(d))
+		if err != nil {
+			return fmterr.Errorf("error creating OpenTelekomCloud NetworkingV2 client: %w", err)
+		}
+
+		tagList := common.ExpandResourceTags(tagRaw)
+		if err := tags.Create(networkingV2Client, "publicips", eip.ID, tagList).ExtractErr(); err != nil {
+			return fmterr.Errorf("error setting tags of EIP %s: %w", eip.ID, err)
+		}
 	}
 
 	return resourceVpcEIPV1Read(ctx, d, meta)
@@ -193,8 +210,19 @@ func resourceVpcEIPV1Read(_ context.Context, d *schema.ResourceData, meta interf
 		return diag.FromErr(err)
 	}
 
-	if err := readNetworkingTags(d, config, "publicips"); err != nil {
-		return diag.FromErr(err)
+	// save tags
+	networkingV2Client, err := config.NetworkingV2Client(config.GetRegion(d))
+	if err != nil {
+		return fmterr.Errorf("error creating OpenTelekomCloud NetworkingV2 client: %w", err)
+	}
+	resourceTags, err := tags.Get(networkingV2Client, "publicips", d.Id()).Extract()
+	if err != nil {
+		return fmterr.Errorf("error fetching tags of EIP %s: %w", d.Id(), err)
+	}
+
+	tagMap := common.TagsToMap(resourceTags)
+	if err := common.SetResourceTags(d, meta, tagMap); err != nil {
+		return fmterr.Errorf("error saving tags of EIP %s: %w", d.Id(), err)
 	}
 
 	return nil
@@ -246,7 +274,7 @@ func resourceVpcEIPV1Update(ctx context.Context, d *schema.ResourceData, meta in
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if d.HasChange("tags_all") {
 		NetworkingV2Client, err := config.NetworkingV2Client(config.GetRegion(d))
 		if err != nil {
 			return fmterr.Errorf("error creating OpenTelekomCloud NetworkingV2 client: %s", err)
@@ -33,6 +33,11 @@ func ResourceVpcSubnetV1() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: common.MultipleCustomizeDiffs(
+			common.SetTagsAllDiff,
+			common.ValidateQuota(common.RequireQuota(common.QuotaSubnets, 1)),
+		),
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -96,7 +101,8 @@ func ResourceVpcSubnetV1() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsAllSchema(),
 			"ntp_addresses": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -171,7 +177,7 @@ func resourceVpcSubnetV1Create(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	// set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := d.Get("tags_all").(map[string]interface{})
 	if len(tagRaw) > 0 {
 		networkingV2Client, err := config.NetworkingV2Client(config.GetRegion(d))
 		if err != nil {
@@ -245,7 +251,7 @@ func resourceVpcSubnetV1Read(_ context.Context, d *schema.ResourceData, meta int
 	}
 
 	tagMap := common.TagsToMap(resourceTags)
-	if err := d.Set("tags", tagMap); err != nil {
+	if err := common.SetResourceTags(d, meta, tagMap); err != nil {
 		return fmterr.Errorf("error saving tags for OpenTelekomCloud VPC Subnet %s: %w", d.Id(), err)
 	}
 
@@ -295,7 +301,7 @@ func resourceVpcSubnetV1Update(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if d.HasChange("tags_all") {
 		networkingV2Client, err := config.NetworkingV2Client(config.GetRegion(d))
 		if err != nil {
 			return fmterr.Errorf("error creating OpenTelekomCloud NetworkingV2 client: %s", err)
@@ -33,6 +33,11 @@ func ResourceVirtualPrivateCloudV1() *schema.Resource {
 			Delete: schema.DefaultTimeout(3 * time.Minute),
 		},
 
+		CustomizeDiff: common.MultipleCustomizeDiffs(
+			common.SetTagsAllDiff,
+			common.ValidateQuota(common.RequireQuota(common.QuotaVPCs, 1)),
+		),
+
 		Schema: map[string]*schema.Schema{ // request and response parameters
 			"region": {
 				Type:     schema.TypeString,
@@ -62,14 +67,15 @@ func ResourceVirtualPrivateCloudV1() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsAllSchema(),
 		},
 	}
 }
 
 func addNetworkingTags(d *schema.ResourceData, config *cfg.Config, res string) error {
 	// set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := d.Get("tags_all").(map[string]interface{})
 	if len(tagRaw) > 0 {
 		vpcV2Client, err := config.NetworkingV2Client(config.GetRegion(d))
 		if err != nil {
@@ -95,7 +101,7 @@ func readNetworkingTags(d *schema.ResourceData, config *cfg.Config, resource str
 	}
 
 	tagMap := common.TagsToMap(resourceTags)
-	if err := d.Set("tags", tagMap); err != nil {
+	if err := common.SetResourceTags(d, config, tagMap); err != nil {
 		return fmt.Errorf("error setting tags: %s", err)
 	}
 	return nil
@@ -216,7 +222,7 @@ func resourceVirtualPrivateCloudV1Update(ctx context.Context, d *schema.Resource
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if d.HasChange("tags_all") {
 		vpcV2Client, err := config.NetworkingV2Client(config.GetRegion(d))
 		if err != nil {
 			return fmterr.Errorf("error creating OpenTelekomCloud networking client: %s", err)
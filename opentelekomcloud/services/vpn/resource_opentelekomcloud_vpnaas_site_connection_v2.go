@@ -34,6 +34,8 @@ func ResourceVpnSiteConnectionV2() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: common.SetTagsAllDiff,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -143,7 +145,8 @@ func ResourceVpnSiteConnectionV2() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsAllSchema(),
 		},
 	}
 }
@@ -218,7 +221,7 @@ func resourceVpnSiteConnectionV2Create(ctx context.Context, d *schema.ResourceDa
 	d.SetId(conn.ID)
 
 	// create tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := d.Get("tags_all").(map[string]interface{})
 	if len(tagRaw) > 0 {
 		taglist := common.ExpandResourceTags(tagRaw)
 		if tagErr := tags.Create(networkingClient, "ipsec-site-connections", d.Id(), taglist).ExtractErr(); tagErr != nil {
@@ -283,7 +286,7 @@ func resourceVpnSiteConnectionV2Read(_ context.Context, d *schema.ResourceData,
 	}
 
 	tagmap := common.TagsToMap(resourceTags)
-	if err := d.Set("tags", tagmap); err != nil {
+	if err := common.SetResourceTags(d, meta, tagmap); err != nil {
 		return fmterr.Errorf("error saving tags for VPN site connection %s: %s", d.Id(), err)
 	}
 